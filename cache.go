@@ -0,0 +1,166 @@
+package html2data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cacheEntry - cached or recorded response in file of URLCfg.CacheDir/RecordDir
+type cacheEntry struct {
+	URL        string      `json:"url"`
+	FinalURL   string      `json:"final_url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	StoredAt   time.Time   `json:"stored_at"`
+}
+
+// fetchWithCache - get response from cache if it is fresh, or revalidate it by conditional request (ETag/Last-Modified)
+func fetchWithCache(request *http.Request, config URLCfg, jar http.CookieJar) (page fetchedPage, err error) {
+	fileName := cacheFileName(config.CacheDir, request)
+	entry, cached := readCacheEntry(fileName)
+	if cached && entry.isFresh(config.CacheTTL) {
+		return entry.page(), nil
+	}
+
+	if cached {
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			request.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+			request.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	page, err = fetchHTTP(request, config, jar)
+	if err != nil {
+		return page, err
+	}
+
+	switch {
+	case cached && page.StatusCode == http.StatusNotModified:
+		for name, values := range page.Header {
+			entry.Header[name] = values
+		}
+		entry.StoredAt = time.Now()
+		page = entry.page()
+	case page.StatusCode == http.StatusOK && !hasCacheDirective(page.Header, "no-store"):
+		entry = cacheEntry{
+			URL:        request.URL.String(),
+			FinalURL:   page.URL,
+			StatusCode: page.StatusCode,
+			Header:     page.Header,
+			Body:       page.Body,
+			StoredAt:   time.Now(),
+		}
+	default:
+		return page, nil
+	}
+
+	return page, writeCacheEntry(fileName, entry)
+}
+
+// cacheFileName - name of cache file for request
+func cacheFileName(cacheDir string, request *http.Request) string {
+	hash := sha256.Sum256([]byte(request.Method + " " + request.URL.String()))
+	return filepath.Join(cacheDir, hex.EncodeToString(hash[:])+".json")
+}
+
+// readCacheEntry - read cached response, returns false if not exists or invalid
+func readCacheEntry(fileName string) (entry cacheEntry, ok bool) {
+	content, err := os.ReadFile(fileName) // #nosec
+	if err != nil {
+		return entry, false
+	}
+
+	if err := json.Unmarshal(content, &entry); err != nil || entry.Header == nil {
+		return entry, false
+	}
+
+	return entry, true
+}
+
+// writeCacheEntry - write cached response via temporary file
+func writeCacheEntry(fileName string, entry cacheEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(fileName), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmpFile.Write(content); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), fileName)
+}
+
+// page - cached response as fetched page
+func (entry cacheEntry) page() fetchedPage {
+	return fetchedPage{
+		URL:        entry.FinalURL,
+		StatusCode: entry.StatusCode,
+		Header:     entry.Header,
+		Body:       entry.Body,
+	}
+}
+
+// isFresh - check that cached response can be used without request:
+// by TTL if it is set, or by Cache-Control max-age/Expires headers
+func (entry cacheEntry) isFresh(ttl time.Duration) bool {
+	age := time.Since(entry.StoredAt)
+	if ttl > 0 {
+		return age < ttl
+	}
+
+	if hasCacheDirective(entry.Header, "no-cache") || hasCacheDirective(entry.Header, "no-store") {
+		return false
+	}
+
+	for _, directive := range strings.Split(entry.Header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if strings.EqualFold(name, "max-age") {
+			maxAge, err := strconv.Atoi(strings.Trim(value, `"`))
+			return err == nil && age < time.Duration(maxAge)*time.Second
+		}
+	}
+
+	if expires := entry.Header.Get("Expires"); expires != "" {
+		expiresAt, err := http.ParseTime(expires)
+		return err == nil && time.Now().Before(expiresAt)
+	}
+
+	return false
+}
+
+// hasCacheDirective - check directive in Cache-Control header
+func hasCacheDirective(header http.Header, name string) bool {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive, _, _ = strings.Cut(strings.TrimSpace(directive), "=")
+		if strings.EqualFold(directive, name) {
+			return true
+		}
+	}
+
+	return false
+}
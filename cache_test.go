@@ -0,0 +1,65 @@
+package html2data
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_CacheDir(t *testing.T) {
+	requests, notModified := 0, 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/etag":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				notModified++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+		case "/max-age":
+			w.Header().Set("Cache-Control", "public, max-age=3600")
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store")
+		}
+		_, _ = fmt.Fprintf(w, "<div>%s %d</div>", r.URL.Path, requests)
+	}))
+	defer ts.Close()
+
+	cacheDir := t.TempDir()
+	get := func(path string, config URLCfg) string {
+		config.CacheDir = cacheDir
+		div, err := FromURL(ts.URL+path, config).GetDataSingle("div")
+		if err != nil {
+			t.Errorf("%s: got error: %s", path, err)
+		}
+		return div
+	}
+
+	if first, second := get("/etag", URLCfg{}), get("/etag", URLCfg{}); first != "/etag 1" || second != first || requests != 2 || notModified != 1 {
+		t.Errorf("ETag revalidation: %q, %q, requests: %d, not modified: %d", first, second, requests, notModified)
+	}
+
+	requests = 0
+	if first, second := get("/max-age", URLCfg{}), get("/max-age", URLCfg{}); first != "/max-age 1" || second != first || requests != 1 {
+		t.Errorf("max-age: %q, %q, requests: %d", first, second, requests)
+	}
+
+	requests = 0
+	if first, second := get("/no-store", URLCfg{}), get("/no-store", URLCfg{}); first != "/no-store 1" || second != "/no-store 2" {
+		t.Errorf("no-store: %q, %q", first, second)
+	}
+
+	requests = 0
+	if first, second := get("/ttl", URLCfg{CacheTTL: time.Hour}), get("/ttl", URLCfg{CacheTTL: time.Hour}); first != "/ttl 1" || second != first || requests != 1 {
+		t.Errorf("TTL: %q, %q, requests: %d", first, second, requests)
+	}
+
+	// without TTL and validators response is requested again
+	if third := get("/ttl", URLCfg{}); third != "/ttl 2" {
+		t.Errorf("without TTL: %q", third)
+	}
+}
@@ -0,0 +1,159 @@
+package html2data
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// DefaultAllowedTags - tags with allowed attributes for :cleanhtml pseudo-selector
+var DefaultAllowedTags = map[string][]string{
+	"a":          {"href", "title"},
+	"abbr":       {"title"},
+	"b":          nil,
+	"blockquote": {"cite"},
+	"br":         nil,
+	"caption":    nil,
+	"code":       nil,
+	"dd":         nil,
+	"del":        nil,
+	"div":        nil,
+	"dl":         nil,
+	"dt":         nil,
+	"em":         nil,
+	"figcaption": nil,
+	"figure":     nil,
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"hr":         nil,
+	"i":          nil,
+	"img":        {"src", "alt", "title", "width", "height"},
+	"ins":        nil,
+	"li":         nil,
+	"ol":         nil,
+	"p":          nil,
+	"pre":        nil,
+	"q":          {"cite"},
+	"s":          nil,
+	"small":      nil,
+	"span":       nil,
+	"strong":     nil,
+	"sub":        nil,
+	"sup":        nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         {"colspan", "rowspan"},
+	"tfoot":      nil,
+	"th":         {"colspan", "rowspan"},
+	"thead":      nil,
+	"tr":         nil,
+	"u":          nil,
+	"ul":         nil,
+}
+
+// droppedTags - tags removed with their content by :cleanhtml
+var droppedTags = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"noscript": true,
+	"template": true,
+	"iframe":   true,
+	"object":   true,
+	"embed":    true,
+	"head":     true,
+	"title":    true,
+}
+
+// urlAttrs - attributes which contain URL and must be checked for unsafe schemes
+var urlAttrs = map[string]bool{
+	"href": true,
+	"src":  true,
+	"cite": true,
+}
+
+// cleanHTML - get HTML of selection with only allowed tags and attributes,
+// not allowed tags are unwrapped, scripts and styles are removed with content
+func cleanHTML(selection *goquery.Selection, allowedTags map[string][]string) (string, error) {
+	if allowedTags == nil {
+		allowedTags = DefaultAllowedTags
+	}
+
+	buf := bytes.Buffer{}
+	for _, node := range selection.Nodes {
+		for _, cleanNode := range cleanNode(node, allowedTags) {
+			if err := html.Render(&buf, cleanNode); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// cleanNode - get sanitized copy of node, returns children for unwrapped tags
+func cleanNode(node *html.Node, allowedTags map[string][]string) []*html.Node {
+	switch node.Type {
+	case html.TextNode:
+		return []*html.Node{{Type: html.TextNode, Data: node.Data}}
+	case html.ElementNode:
+		// see below
+	default:
+		return nil
+	}
+
+	tagName := strings.ToLower(node.Data)
+	if droppedTags[tagName] {
+		return nil
+	}
+
+	children := []*html.Node{}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		children = append(children, cleanNode(child, allowedTags)...)
+	}
+
+	allowedAttrs, ok := allowedTags[tagName]
+	if !ok {
+		return children
+	}
+
+	result := &html.Node{Type: html.ElementNode, Data: tagName, DataAtom: node.DataAtom}
+	for _, attr := range node.Attr {
+		if isAllowedAttr(attr, allowedAttrs) {
+			result.Attr = append(result.Attr, html.Attribute{Key: attr.Key, Val: attr.Val})
+		}
+	}
+	for _, child := range children {
+		result.AppendChild(child)
+	}
+
+	return []*html.Node{result}
+}
+
+// isAllowedAttr - check attribute in allowed list, event handlers and javascript: URLs are never allowed
+func isAllowedAttr(attr html.Attribute, allowedAttrs []string) bool {
+	key := strings.ToLower(attr.Key)
+	if attr.Namespace != "" || strings.HasPrefix(key, "on") {
+		return false
+	}
+
+	if urlAttrs[key] {
+		scheme := strings.ToLower(strings.Join(strings.Fields(attr.Val), ""))
+		if strings.HasPrefix(scheme, "javascript:") || strings.HasPrefix(scheme, "vbscript:") || strings.HasPrefix(scheme, "data:text/html") {
+			return false
+		}
+	}
+
+	for _, allowed := range allowedAttrs {
+		if key == allowed {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package html2data
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_cleanHTML(t *testing.T) {
+	testData := []struct {
+		html    string
+		allowed map[string][]string
+		out     string
+	}{
+		{
+			html: `<div><p class="x" onclick="evil()">text <b>bold</b></p></div>`,
+			out:  `<div><p>text <b>bold</b></p></div>`,
+		}, {
+			html: `<div><script>alert(1)</script><style>p{}</style><p>text</p></div>`,
+			out:  `<div><p>text</p></div>`,
+		}, {
+			html: `<div><custom-tag><i>text</i></custom-tag></div>`,
+			out:  `<div><i>text</i></div>`,
+		}, {
+			html: `<div><a href="javascript:alert(1)" title="t">link</a><a href=" JavaScript :x">l2</a><a href="http://url">l3</a></div>`,
+			out:  `<div><a title="t">link</a><a>l2</a><a href="http://url">l3</a></div>`,
+		}, {
+			html: `<div><img src="1.png" onerror="evil()" alt="a"/><!-- comment --></div>`,
+			out:  `<div><img src="1.png" alt="a"/></div>`,
+		}, {
+			html:    `<div><p class="x">text <b>bold</b></p></div>`,
+			allowed: map[string][]string{"div": nil, "p": {"class"}},
+			out:     `<div><p class="x">text bold</p></div>`,
+		}, {
+			html:    `<div><p>1 &lt; 2</p></div>`,
+			allowed: map[string][]string{"p": nil},
+			out:     `<p>1 &lt; 2</p>`,
+		},
+	}
+
+	for i, item := range testData {
+		out, err := FromReader(strings.NewReader(item.html)).GetDataSingle("div:get(1):cleanhtml", Cfg{AllowedTags: item.allowed})
+		if err != nil {
+			t.Errorf("%d. got error: %s", i, err)
+		}
+		if out != item.out {
+			t.Errorf("%d. expected: %#v, real: %#v", i, item.out, out)
+		}
+	}
+}
@@ -2,27 +2,68 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
 
 	"github.com/msoap/html2data"
 )
 
 const usageString = "Usage:\n" +
 	"  html2data [options] [url|file|-] 'css selector'\n" +
-	"  html2data [options] [url|file|-] :name1 'css1' :name2 'css2' ...\n\n" +
+	"  html2data [options] [url|file|-] :name1 'css1' :name2 'css2' ...\n" +
+	"  html2data [options] url|file|glob ... :name1 'css1' :name2 'css2' ...\n" +
+	"  html2data -r [-include glob] [-exclude glob] [options] dir ... :name1 'css1' :name2 'css2' ...\n" +
+	"  html2data -i [options] url|file [:name1 'css1' ...]\n" +
+	"  html2data -readable [options] [url|file|-] ...\n" +
+	"  html2data -links|-assets [options] [url|file|-] ...\n" +
+	"  html2data -sitemap URL [options] :name1 'css1' :name2 'css2' ...\n" +
+	"  html2data -warc file.warc.gz [options] :name1 'css1' :name2 'css2' ...\n" +
+	"  html2data -har file.har [options] :name1 'css1' :name2 'css2' ...\n" +
+	"  html2data suggest [options] url|file 'example text'\n" +
+	"  html2data serve [-listen :8080] (see html2data serve -help)\n\n" +
 	"options:"
 
 type cmdConfig struct {
-	userAgent, outerCSS, url string
-	timeOut                  int
-	getJSON                  bool
-	dontTrimSpaces           bool
-	dontDetectCharset        bool
+	userAgent, outerCSS  string
+	sources              []string
+	inputList            string
+	parallel             int
+	recursive            bool
+	include, exclude     stringsFlag
+	format               string
+	templateText         string
+	templateFile         string
+	template             *template.Template
+	nextCSS              string
+	sitemap              string
+	warc                 string
+	har                  string
+	xml                  bool
+	cacheDir             string
+	cacheTTL             time.Duration
+	recordDir, replayDir string
+	timeOut              int
+	maxPages             int
+	getJSON              bool
+	dontTrimSpaces       bool
+	dontDetectCharset    bool
+	readable             bool
+	links                bool
+	assets               bool
+	respectRobots        bool
+	interactive          bool
+	explain              bool
 }
 
 var (
@@ -32,10 +73,34 @@ var (
 func init() {
 	flag.StringVar(&config.userAgent, "user-agent", "", "set custom user-agent")
 	flag.StringVar(&config.outerCSS, "find-in", "", "search in the specified elements instead document")
-	flag.BoolVar(&config.getJSON, "json", false, "JSON output")
+	flag.BoolVar(&config.getJSON, "json", false, "JSON output, the same as -format json")
+	flag.StringVar(&config.format, "format", "text", "output `format`: text, json, ndjson (JSON line per element of -find-in)")
+	flag.StringVar(&config.templateText, "template", "", "output each result by Go `template`, e.g. '{{.title}} - {{first .url}}'")
+	flag.StringVar(&config.templateFile, "template-file", "", "output each result by Go template from `file`")
 	flag.BoolVar(&config.dontTrimSpaces, "dont-trim-spaces", false, "don't trim spaces, get text as is")
 	flag.BoolVar(&config.dontDetectCharset, "dont-detect-charset", false, "don't detect charset and convert text")
+	flag.BoolVar(&config.explain, "explain", false, "print to stderr how each selector is parsed and which elements it matched (DOM path, line:column)")
+	flag.BoolVar(&config.interactive, "i", false, "interactive mode: load document once and try selectors, build recipe (type /help)")
+	flag.BoolVar(&config.readable, "readable", false, "extract main content (article) of page instead of selectors")
+	flag.BoolVar(&config.links, "links", false, "get all links of page with absolute URLs instead of selectors")
+	flag.BoolVar(&config.assets, "assets", false, "get all images, scripts, stylesheets, iframes of page instead of selectors")
+	flag.StringVar(&config.nextCSS, "next", "", "follow next page link found by `css selector` (href attribute by default) and extract data from each page")
+	flag.IntVar(&config.maxPages, "max-pages", 0, "max `count` of pages for -next, 0 - without limit")
+	flag.StringVar(&config.sitemap, "sitemap", "", "extract data from each page listed in sitemap `URL` (or file), output as JSON line per page")
+	flag.StringVar(&config.warc, "warc", "", "extract data from each HTML response in WARC `file` (or .warc.gz), output as JSON line per response")
+	flag.StringVar(&config.har, "har", "", "extract data from each HTML response in HAR `file` exported from browser, output as JSON line per response")
+	flag.BoolVar(&config.xml, "xml", false, "parse input as XML (RSS, Atom...): case-sensitive names, namespaces as 'media|content'")
+	flag.StringVar(&config.cacheDir, "cache-dir", "", "cache responses in `directory`, revalidate them by ETag/Last-Modified")
+	flag.DurationVar(&config.cacheTTL, "cache-ttl", 0, "use cached responses without revalidation for this `duration` (e.g. 1h)")
+	flag.StringVar(&config.recordDir, "record", "", "record all responses to `directory` for -replay")
+	flag.StringVar(&config.replayDir, "replay", "", "get responses from `directory` recorded by -record, without network")
+	flag.BoolVar(&config.respectRobots, "respect-robots", false, "check robots.txt before loading URLs")
 	flag.IntVar(&config.timeOut, "timeout", 0, "timeout in `seconds`")
+	flag.StringVar(&config.inputList, "input-list", "", "read list of URLs or files from `file` (one per line, - for stdin)")
+	flag.IntVar(&config.parallel, "parallel", 1, "process `N` sources concurrently")
+	flag.BoolVar(&config.recursive, "r", false, "extract data from files in directories recursively, results are keyed by relative path")
+	flag.Var(&config.include, "include", "glob `pattern` of files for -r, \"*.html\" and \"*.htm\" (also .gz, .bz2, .zst) by default (can be repeated)")
+	flag.Var(&config.exclude, "exclude", "glob `pattern` of skipped files and directories for -r (can be repeated)")
 }
 
 func getConfig() (CSSSelectors map[string]string, err error) {
@@ -46,82 +111,626 @@ func getConfig() (CSSSelectors map[string]string, err error) {
 	}
 	flag.Parse()
 
-	config.url, CSSSelectors, err = parseArgs(flag.Args())
-	return CSSSelectors, err
+	switch {
+	case config.getJSON && (config.format == "" || config.format == "text"):
+		config.format = "json"
+	case config.format == "ndjson" || config.format == "json":
+		config.getJSON = true
+	case config.format == "" || config.format == "text":
+		config.format = "text"
+	default:
+		return nil, fmt.Errorf("unknown output format: %s", config.format)
+	}
+
+	if config.templateText != "" || config.templateFile != "" {
+		if config.template, err = parseTemplate(config.templateText, config.templateFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.readable || config.links || config.assets || config.interactive && len(flag.Args()) == 1 {
+		config.sources = parseSourcesArgs(flag.Args())
+	} else if config.sources, CSSSelectors, err = parseArgs(flag.Args()); err != nil {
+		return CSSSelectors, err
+	}
+
+	if config.inputList != "" {
+		list, err := readInputList(config.inputList)
+		if err != nil {
+			return CSSSelectors, err
+		}
+		if len(config.sources) == 1 && config.sources[0] == "-" {
+			config.sources = nil
+		}
+		config.sources = append(config.sources, list...)
+	}
+
+	config.sources = expandGlobs(config.sources)
+	return CSSSelectors, nil
 }
 
 // printAsText - print result as text
-func printAsText(texts map[string][]string, doPrintName bool) {
+func printAsText(out io.Writer, texts map[string][]string, doPrintName bool) error {
+	buf := bytes.Buffer{}
 	for name, value := range texts {
 		if doPrintName {
-			fmt.Print(name + ":\t")
+			buf.WriteString(name + ":\t")
 		}
 		for _, text := range value {
-			fmt.Println(text)
+			buf.WriteString(text + "\n")
 		}
 	}
+
+	_, err := out.Write(buf.Bytes())
+	return err
 }
 
-func runApp() error {
-	CSSSelectors, err := getConfig()
+// printReadable - print main content of document
+func printReadable(out io.Writer, doc html2data.Doc) error {
+	article, err := doc.MainContent()
 	if err != nil {
 		return err
 	}
-	var doc html2data.Doc
-	stat, err := os.Stdin.Stat()
+
+	if config.getJSON {
+		return printJSON(out, article)
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteString(article.Title + "\n")
+	if article.Byline != "" {
+		buf.WriteString(article.Byline + "\n")
+	}
+	if article.Published != nil {
+		buf.WriteString(article.Published.Format(time.RFC3339) + "\n")
+	}
+	buf.WriteString("\n" + article.Text + "\n")
+
+	_, err = out.Write(buf.Bytes())
+	return err
+}
+
+// getLinks - get links and/or assets of document
+func getLinks(doc html2data.Doc) (links []html2data.Link, assets []html2data.Asset, err error) {
+	if config.links {
+		if links, err = doc.Links(); err != nil {
+			return links, assets, err
+		}
+	}
+	if config.assets {
+		if assets, err = doc.Assets(); err != nil {
+			return links, assets, err
+		}
+	}
+
+	return links, assets, nil
+}
+
+// linksValue - links and/or assets for JSON output
+func linksValue(links []html2data.Link, assets []html2data.Asset) interface{} {
+	switch {
+	case config.links && config.assets:
+		return map[string]interface{}{"links": links, "assets": assets}
+	case config.assets:
+		return assets
+	default:
+		return links
+	}
+}
+
+// printLinks - print links and/or assets of document
+func printLinks(out io.Writer, doc html2data.Doc) error {
+	links, assets, err := getLinks(doc)
 	if err != nil {
 		return err
 	}
 
-	if config.url == "-" || (stat.Mode()&os.ModeCharDevice) == 0 {
-		reader := bufio.NewReader(os.Stdin)
-		doc = html2data.FromReader(reader)
-	} else if strings.HasPrefix(config.url, "http://") || strings.HasPrefix(config.url, "https://") {
-		doc = html2data.FromURL(config.url, html2data.URLCfg{UA: config.userAgent, TimeOut: config.timeOut, DontDetectCharset: config.dontDetectCharset})
-	} else if len(config.url) > 0 {
-		doc = html2data.FromFile(config.url)
-	} else {
-		fmt.Println(usageString)
+	if config.format == "ndjson" {
+		for _, link := range links {
+			if err := printJSON(out, link); err != nil {
+				return err
+			}
+		}
+		for _, asset := range assets {
+			if err := printJSON(out, asset); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 
-	GetDocCfg := html2data.Cfg{DontTrimSpaces: config.dontTrimSpaces}
+	if config.getJSON {
+		return printJSON(out, linksValue(links, assets))
+	}
+
+	buf := bytes.Buffer{}
+	for _, link := range links {
+		fmt.Fprintf(&buf, "%s\t%s\n", link.URL, link.Text)
+	}
+	for _, asset := range assets {
+		fmt.Fprintf(&buf, "%s\t%s\n", asset.Type, asset.URL)
+	}
+
+	_, err = out.Write(buf.Bytes())
+	return err
+}
+
+// urlConfig - config for load URLs from command line options
+func urlConfig() html2data.URLCfg {
+	return html2data.URLCfg{
+		UA:                config.userAgent,
+		TimeOut:           config.timeOut,
+		DontDetectCharset: config.dontDetectCharset,
+		RespectRobots:     config.respectRobots,
+		CacheDir:          config.cacheDir,
+		CacheTTL:          config.cacheTTL,
+		RecordDir:         config.recordDir,
+		ReplayDir:         config.replayDir,
+		XML:               config.xml,
+		KeepSource:        config.explain,
+	}
+}
+
+// readerConfig - config for parse files and stdin, source is kept for positions of elements in -explain
+func readerConfig() html2data.ReaderCfg {
+	return html2data.ReaderCfg{XML: config.xml, KeepSource: config.explain}
+}
+
+// docConfig - config for extract data from document, with report of selectors to stderr for -explain
+func docConfig() html2data.Cfg {
+	cfg := html2data.Cfg{DontTrimSpaces: config.dontTrimSpaces}
+	if config.explain {
+		cfg.Trace = func(trace html2data.SelectorTrace) {
+			// one write for report of selector, documents can be processed concurrently
+			_ = printExplain(os.Stderr, trace)
+		}
+	}
+
+	return cfg
+}
+
+// printExplain - print how selector is parsed and which nodes it matched, with one write
+func printExplain(out io.Writer, trace html2data.SelectorTrace) error {
+	buf := bytes.Buffer{}
+	if trace.Name == "" {
+		fmt.Fprintf(&buf, "find-in: %s\n", trace.Selector)
+	} else {
+		fmt.Fprintf(&buf, ":%s %s\n", trace.Name, trace.Selector)
+	}
+	if trace.Outer != "" {
+		fmt.Fprintf(&buf, "  in: %s\n", trace.Outer)
+	}
+	fmt.Fprintf(&buf, "  css: %s\n", trace.BaseSelector)
+	if len(trace.PseudoSelectors) > 0 {
+		fmt.Fprintf(&buf, "  pseudo: :%s\n", strings.Join(trace.PseudoSelectors, ", :"))
+	}
+
+	skipped := 0
+	for _, node := range trace.Nodes {
+		if node.Skipped {
+			skipped++
+		}
+	}
+	fmt.Fprintf(&buf, "  matched: %d", len(trace.Nodes))
+	if skipped > 0 {
+		fmt.Fprintf(&buf, ", filtered out by :get(N): %d", skipped)
+	}
+	buf.WriteString("\n")
+
+	for i, node := range trace.Nodes {
+		position := "?"
+		if node.Line > 0 {
+			position = fmt.Sprintf("%d:%d", node.Line, node.Column)
+		}
+		fmt.Fprintf(&buf, "  [%d] %s (line %s)", i+1, node.Path, position)
+		if node.Skipped {
+			buf.WriteString(" - filtered out")
+		}
+		buf.WriteString("\n")
+		for _, value := range node.Values {
+			fmt.Fprintf(&buf, "      %s\n", replValue(value))
+		}
+	}
+
+	_, err := out.Write(buf.Bytes())
+	return err
+}
+
+// docValues - get typed values of document by selectors for JSON output
+func docValues(doc html2data.Doc, CSSSelectors map[string]string) (interface{}, error) {
+	GetDocCfg := docConfig()
 	if config.outerCSS != "" {
+		return doc.GetDataNestedTyped(config.outerCSS, CSSSelectors, GetDocCfg)
+	}
+
+	return doc.GetDataTyped(CSSSelectors, GetDocCfg)
+}
+
+// docResult - get result of document for current mode (selectors, readable, links) for JSON output
+func docResult(doc html2data.Doc, CSSSelectors map[string]string) (interface{}, error) {
+	switch {
+	case config.readable:
+		return doc.MainContent()
+	case config.links || config.assets:
+		links, assets, err := getLinks(doc)
+		return linksValue(links, assets), err
+	default:
+		return docValues(doc, CSSSelectors)
+	}
+}
+
+// printDoc - print result of document for current mode
+func printDoc(out io.Writer, doc html2data.Doc, CSSSelectors map[string]string) error {
+	switch {
+	case config.readable:
+		return printReadable(out, doc)
+	case config.links || config.assets:
+		return printLinks(out, doc)
+	default:
+		return printData(out, doc, CSSSelectors)
+	}
+}
+
+// printData - print data of document by selectors
+func printData(out io.Writer, doc html2data.Doc, CSSSelectors map[string]string) error {
+	GetDocCfg := docConfig()
+	switch {
+	case config.template != nil && config.outerCSS != "":
+		return doc.GetDataNestedFunc(config.outerCSS, CSSSelectors, func(texts map[string][]string) error {
+			return config.template.Execute(out, templateData(texts))
+		}, GetDocCfg)
+	case config.template != nil:
+		texts, err := doc.GetData(CSSSelectors, GetDocCfg)
+		if err != nil {
+			return err
+		}
+
+		return config.template.Execute(out, templateData(texts))
+	case config.format == "ndjson" && config.outerCSS != "":
+		return doc.GetDataNestedTypedFunc(config.outerCSS, CSSSelectors, func(item map[string][]interface{}) error {
+			return printJSON(out, item)
+		}, GetDocCfg)
+	case config.getJSON:
+		values, err := docValues(doc, CSSSelectors)
+		if err != nil {
+			return err
+		}
+
+		return printJSON(out, values)
+	case config.outerCSS != "":
 		textsOuter, err := doc.GetDataNested(config.outerCSS, CSSSelectors, GetDocCfg)
 		if err != nil {
 			return err
 		}
 
-		if config.getJSON {
-			jsonBytes, err := json.Marshal(textsOuter)
-			if err != nil {
+		for i, texts := range textsOuter {
+			if _, err := fmt.Fprintf(out, "%d:\n", i); err != nil {
 				return err
 			}
-			fmt.Println(string(jsonBytes))
-		} else {
-			for i, texts := range textsOuter {
-				fmt.Printf("%d:\n", i)
-				printAsText(texts, len(CSSSelectors) > 1)
+			if err := printAsText(out, texts, len(CSSSelectors) > 1); err != nil {
+				return err
 			}
 		}
-	} else {
+	default:
 		texts, err := doc.GetData(CSSSelectors, GetDocCfg)
 		if err != nil {
 			return err
 		}
 
-		if config.getJSON {
-			jsonBytes, err := json.Marshal(texts)
+		return printAsText(out, texts, len(CSSSelectors) > 1)
+	}
+
+	return nil
+}
+
+// printJSON - print value as JSON line
+func printJSON(out io.Writer, value interface{}) error {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(out, string(jsonBytes))
+	return err
+}
+
+// pageResult - result for one page of many (sources, sitemap, archives) as JSON line
+type pageResult struct {
+	Source string      `json:"source,omitempty"`
+	URL    string      `json:"url,omitempty"`
+	Data   interface{} `json:"data,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// printPageResult - print result of one page of many as JSON line, returns false for failed page
+func printPageResult(out io.Writer, result pageResult, doc html2data.Doc, CSSSelectors map[string]string) bool {
+	values, err := docResult(doc, CSSSelectors)
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Data = values
+	}
+
+	if errJSON := printJSON(out, result); errJSON != nil {
+		result.Data, result.Error = nil, errJSON.Error()
+		_ = printJSON(out, result)
+		return false
+	}
+
+	return err == nil
+}
+
+// processSitemap - extract data from each page of sitemap
+func processSitemap(CSSSelectors map[string]string) error {
+	urls, err := html2data.ReadSitemap(config.sitemap, urlConfig())
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, sitemapURL := range urls {
+		if !printPageResult(os.Stdout, pageResult{URL: sitemapURL.Loc}, html2data.FromURL(sitemapURL.Loc, urlConfig()), CSSSelectors) {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d pages failed", failed, len(urls))
+	}
+
+	return nil
+}
+
+// processArchive - extract data from each HTML response of archive file (WARC, HAR)
+func processArchive(fileName string, read func(io.Reader, func(html2data.ArchiveRecord) error) error, CSSSelectors map[string]string) (err error) {
+	file, err := os.Open(fileName) // #nosec
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errClose := file.Close(); err == nil {
+			err = errClose
+		}
+	}()
+
+	total, failed := 0, 0
+	err = read(file, func(record html2data.ArchiveRecord) error {
+		total++
+		if !printPageResult(os.Stdout, pageResult{URL: record.URL}, record.Doc, CSSSelectors) {
+			failed++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d records failed", failed, total)
+	}
+
+	return nil
+}
+
+// loadDoc - get document from URL, file (.mhtml and .eml as MIME) or stdin ("-")
+func loadDoc(source string) html2data.Doc {
+	switch {
+	case source == "-":
+		return html2data.FromReaderCfg(bufio.NewReader(os.Stdin), readerConfig())
+	case isURL(source):
+		return html2data.FromURL(source, urlConfig())
+	case config.xml:
+		return html2data.FromFileCfg(source, readerConfig())
+	case isMIMEFile(source):
+		return loadFile(source, html2data.FromMIME)
+	default:
+		return html2data.FromFileCfg(source, readerConfig())
+	}
+}
+
+// loadFile - get document from file by parse function
+func loadFile(fileName string, parse func(io.Reader) html2data.Doc) html2data.Doc {
+	file, err := os.Open(fileName) // #nosec
+	if err != nil {
+		return html2data.Doc{Err: err}
+	}
+
+	doc := parse(file)
+	if err := file.Close(); err != nil && doc.Err == nil {
+		doc.Err = err
+	}
+
+	return doc
+}
+
+// sourceDoc - document of one source of many, loaded by worker
+type sourceDoc struct {
+	source string
+	load   func() html2data.Doc
+}
+
+// processDocs - extract data from documents sent by produce concurrently, failed documents don't stop others,
+// results are tagged by source
+func processDocs(CSSSelectors map[string]string, produce func(docs chan<- sourceDoc) error) error {
+	var (
+		mutex         sync.Mutex
+		total, failed int
+		wg            sync.WaitGroup
+	)
+
+	docs := make(chan sourceDoc)
+	for i := 0; i < config.parallel || i == 0; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range docs {
+				out := bytes.Buffer{}
+				doc := item.load()
+				var err error
+				if config.getJSON {
+					if !printPageResult(&out, pageResult{Source: item.source, URL: doc.URL}, doc, CSSSelectors) {
+						err = fmt.Errorf("failed")
+					}
+				} else {
+					if config.template == nil {
+						fmt.Fprintf(&out, "==> %s <==\n", item.source)
+					}
+					err = doc.Err
+					if err == nil {
+						err = printDoc(&out, doc, CSSSelectors)
+					}
+				}
+
+				mutex.Lock()
+				_, _ = os.Stdout.Write(out.Bytes())
+				total++
+				if err != nil {
+					failed++
+					if !config.getJSON {
+						log.Printf("%s: %s", item.source, err)
+					}
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	err := produce(docs)
+	close(docs)
+	wg.Wait()
+
+	switch {
+	case err != nil:
+		return err
+	case failed > 0:
+		return fmt.Errorf("%d of %d sources failed", failed, total)
+	}
+
+	return nil
+}
+
+// processSources - extract data from many URLs or files
+func processSources(CSSSelectors map[string]string) error {
+	return processDocs(CSSSelectors, func(docs chan<- sourceDoc) error {
+		for _, source := range config.sources {
+			source := source
+			docs <- sourceDoc{source: source, load: func() html2data.Doc { return loadDoc(source) }}
+		}
+		return nil
+	})
+}
+
+// processDirs - extract data from files in directories recursively, results are keyed by relative path
+// (prefixed by directory if there are many directories)
+func processDirs(CSSSelectors map[string]string) error {
+	dirCfg := html2data.DirCfg{Include: config.include, Exclude: config.exclude}
+	return processDocs(CSSSelectors, func(docs chan<- sourceDoc) error {
+		for _, dir := range config.sources {
+			if dir == "-" {
+				dir = "."
+			}
+			// files are parsed in workers of processDocs
+			err := html2data.WalkDir(dir, func(relPath string) error {
+				filePath := filepath.Join(dir, filepath.FromSlash(relPath))
+				if len(config.sources) > 1 {
+					relPath = path.Join(filepath.ToSlash(dir), relPath)
+				}
+				docs <- sourceDoc{source: relPath, load: func() html2data.Doc { return html2data.FromFileCfg(filePath, readerConfig()) }}
+				return nil
+			}, dirCfg)
 			if err != nil {
 				return err
 			}
-			fmt.Println(string(jsonBytes))
-		} else {
-			printAsText(texts, len(CSSSelectors) > 1)
 		}
+		return nil
+	})
+}
+
+func runApp() error {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		return runServe(os.Args[2:])
+	}
+	if len(os.Args) > 1 && os.Args[1] == "suggest" {
+		return runSuggest(os.Args[2:])
+	}
+
+	CSSSelectors, err := getConfig()
+	if err != nil {
+		return err
+	}
+
+	if config.sitemap != "" {
+		if len(config.sources) != 1 || config.sources[0] != "-" {
+			return fmt.Errorf("url or file is not allowed with -sitemap option")
+		}
+		return processSitemap(CSSSelectors)
 	}
 
-	return nil
+	if config.warc != "" || config.har != "" {
+		if len(config.sources) != 1 || config.sources[0] != "-" {
+			return fmt.Errorf("url or file is not allowed with -warc/-har options")
+		}
+		if config.warc != "" {
+			return processArchive(config.warc, html2data.ReadWARC, CSSSelectors)
+		}
+		return processArchive(config.har, html2data.ReadHAR, CSSSelectors)
+	}
+
+	if config.interactive {
+		if len(config.sources) != 1 || config.sources[0] == "-" {
+			return fmt.Errorf("-i option works only with one URL or file, stdin is used for input selectors")
+		}
+		doc := loadDoc(config.sources[0])
+		if doc.Err != nil {
+			return doc.Err
+		}
+		return newREPL(doc, os.Stdout, recipe{FindIn: config.outerCSS, Selectors: CSSSelectors}, replHistoryPath()).run(os.Stdin)
+	}
+
+	if config.recursive {
+		if config.nextCSS != "" {
+			return fmt.Errorf("-next option is not allowed with -r option")
+		}
+		return processDirs(CSSSelectors)
+	}
+
+	if len(config.sources) > 1 || config.inputList != "" {
+		if config.nextCSS != "" {
+			return fmt.Errorf("-next option works only with one URL")
+		}
+		return processSources(CSSSelectors)
+	}
+	source := ""
+	if len(config.sources) == 1 {
+		source = config.sources[0]
+	}
+
+	if config.nextCSS != "" {
+		if !isURL(source) {
+			return fmt.Errorf("-next option works only with http(s) URL")
+		}
+
+		return html2data.Paginate(source, config.nextCSS, func(doc html2data.Doc) error {
+			return printData(os.Stdout, doc, CSSSelectors)
+		}, html2data.PaginateCfg{URLCfg: urlConfig(), MaxPages: config.maxPages})
+	}
+
+	var doc html2data.Doc
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return err
+	}
+
+	if source == "-" || (stat.Mode()&os.ModeCharDevice) == 0 {
+		doc = loadDoc("-")
+	} else if len(source) > 0 {
+		doc = loadDoc(source)
+	} else {
+		fmt.Println(usageString)
+		return nil
+	}
+
+	return printDoc(os.Stdout, doc, CSSSelectors)
 }
 
 func main() {
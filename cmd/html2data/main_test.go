@@ -7,8 +7,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/msoap/html2data"
 )
 
 func mainWrapper(t *testing.T, args []string) (out string, err error) {
@@ -63,6 +66,12 @@ func Test_main(t *testing.T) {
 		t.Errorf("2. main() failed: got: '%s'", out)
 	}
 
+	// one selector beginning from ":"
+	out, err = mainWrapper(t, []string{"html2data", "test.html", ":root > head > title"})
+	if err != nil || out != "Title" {
+		t.Errorf("2.1. main() failed: got: '%s'", out)
+	}
+
 	// plain text nested
 	out, err = mainWrapper(t, []string{"html2data", "-find-in=div.article", "test.html", "h1"})
 	if err != nil || out != "0:\nHead1\nHead2" {
@@ -87,6 +96,36 @@ func Test_main(t *testing.T) {
 		t.Errorf("6. main() failed: got: '%s'", out)
 	}
 
+	// ndjson nested
+	out, err = mainWrapper(t, []string{"html2data", "-format", "ndjson", "-find-in=div.block", "test.html", "h1"})
+	if err != nil || out != `{"one":["Head1.1","Head1.2"]}`+"\n"+`{"one":["Head2.1","Head2.2"]}` {
+		t.Errorf("6.0. main() failed: got: '%s'", out)
+	}
+
+	// template
+	out, err = mainWrapper(t, []string{"html2data", "-template", `{{.head | join ", "}} - {{first .link}}{{.none | default " -"}}`, "-find-in=div.block", "test.html", ":head", "h1", ":link", "a:attr(href)"})
+	if err != nil || out != "Head1.1, Head1.2 - http://url1 -\nHead2.1, Head2.2 - http://url2 -" {
+		t.Errorf("6.01. main() failed: got: '%s'", out)
+	}
+
+	// json with typed values
+	out, err = mainWrapper(t, []string{"html2data", "-json", "test.html", ":price", "span.price:number", ":count", "span.count:int"})
+	if err != nil || out != `{"count":[1234],"price":[1234.5]}` {
+		t.Errorf("6.1. main() failed: got: '%s'", out)
+	}
+
+	// readable
+	out, err = mainWrapper(t, []string{"html2data", "-readable", "test.html"})
+	if err != nil || !strings.HasPrefix(out, "Title\n\nHead1") {
+		t.Errorf("6.2. main() failed: got: '%s'", out)
+	}
+
+	// links
+	out, err = mainWrapper(t, []string{"html2data", "-links", "-json", "test.html"})
+	if err != nil || !strings.HasPrefix(out, `[{"url":"url","text":"link","internal":true},`) {
+		t.Errorf("6.3. main() failed: got: '%s'", out)
+	}
+
 	// from URL
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		_, _ = fmt.Fprintln(w, "<div>data</div>")
@@ -96,9 +135,159 @@ func Test_main(t *testing.T) {
 		t.Errorf("7. main() failed: got: '%s'", out)
 	}
 
+	// record
+	fixtures := t.TempDir()
+	out, err = mainWrapper(t, []string{"html2data", "-record", fixtures, ts.URL, "div"})
+	if err != nil || out != "data" {
+		t.Errorf("7.0. main() failed: got: '%s'", out)
+	}
+	ts.Close()
+
+	// replay
+	out, err = mainWrapper(t, []string{"html2data", "-replay", fixtures, ts.URL, "div"})
+	if err != nil || out != "data" {
+		t.Errorf("7.0.1. main() failed: got: '%s'", out)
+	}
+
+	// pagination
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `<div>%s</div><a class="next" href="%s/next">next</a>`, r.URL.Path, r.URL.Path)
+	}))
+	out, err = mainWrapper(t, []string{"html2data", "-json", "-next", "a.next", "-max-pages", "3", ts.URL + "/p", "div"})
+	if err != nil || out != `{"one":["/p"]}`+"\n"+`{"one":["/p/next"]}`+"\n"+`{"one":["/p/next/next"]}` {
+		t.Errorf("7.1. main() failed: got: '%s'", out)
+	}
+	ts.Close()
+
+	// sitemap
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/sitemap.xml" {
+			_, _ = fmt.Fprint(w, "<urlset><url><loc>http://"+r.Host+"/p1</loc></url><url><loc>http://"+r.Host+"/p2</loc></url></urlset>")
+			return
+		}
+		_, _ = fmt.Fprintf(w, `<div>%s</div>`, r.URL.Path)
+	}))
+	out, err = mainWrapper(t, []string{"html2data", "-sitemap", ts.URL + "/sitemap.xml", ":div", "div"})
+	if err != nil || out != `{"url":"`+ts.URL+`/p1","data":{"div":["/p1"]}}`+"\n"+`{"url":"`+ts.URL+`/p2","data":{"div":["/p2"]}}` {
+		t.Errorf("7.2. main() failed: got: '%s'", out)
+	}
+	ts.Close()
+
+	// many sources
+	out, err = mainWrapper(t, []string{"html2data", "test.html", "test.htm?", "title"})
+	if err != nil || out != "==> test.html <==\nTitle\n==> test.html <==\nTitle" {
+		t.Errorf("7.3. main() failed: got: '%s'", out)
+	}
+
+	// many sources as json
+	out, err = mainWrapper(t, []string{"html2data", "-json", "-parallel=2", "test.html", "test.html", "title"})
+	if err != nil || out != `{"source":"test.html","data":{"one":["Title"]}}`+"\n"+`{"source":"test.html","data":{"one":["Title"]}}` {
+		t.Errorf("7.4. main() failed: got: '%s'", out)
+	}
+
+	// recursive
+	dir := t.TempDir()
+	for name, content := range map[string]string{"a.html": "<title>A</title>", "sub/b.html": "<title>B</title>", "skip.html": "<title>C</title>"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out, err = mainWrapper(t, []string{"html2data", "-json", "-r", "-exclude", "skip.html", dir, "title"})
+	if err != nil || out != `{"source":"a.html","data":{"one":["A"]}}`+"\n"+`{"source":"sub/b.html","data":{"one":["B"]}}` {
+		t.Errorf("7.5. main() failed: got: '%s'", out)
+	}
+
+	// warc
+	block := "HTTP/1.1 200 OK\r\nContent-Type: text/html\r\n\r\n<title>Archived</title>"
+	warc := fmt.Sprintf("WARC/1.0\r\nWARC-Type: response\r\nWARC-Target-URI: http://example.com/\r\n"+
+		"Content-Type: application/http; msgtype=response\r\nContent-Length: %d\r\n\r\n%s\r\n\r\n", len(block), block)
+	if err := os.WriteFile(filepath.Join(dir, "crawl.warc"), []byte(warc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, err = mainWrapper(t, []string{"html2data", "-warc", filepath.Join(dir, "crawl.warc"), ":title", "title"})
+	if err != nil || out != `{"url":"http://example.com/","data":{"title":["Archived"]}}` {
+		t.Errorf("7.6. main() failed: got: '%s'", out)
+	}
+
+	// har
+	har := `{"log": {"entries": [{"request": {"url": "http://example.com/"}, "response": {"status": 200, "content": {"mimeType": "text/html", "text": "<title>Rendered</title>"}}}]}}`
+	if err := os.WriteFile(filepath.Join(dir, "session.har"), []byte(har), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, err = mainWrapper(t, []string{"html2data", "-har", filepath.Join(dir, "session.har"), "title"})
+	if err != nil || out != `{"url":"http://example.com/","data":{"one":["Rendered"]}}` {
+		t.Errorf("7.7. main() failed: got: '%s'", out)
+	}
+
+	// xml
+	if err := os.WriteFile(filepath.Join(dir, "feed.xml"), []byte(`<rss><channel><item><pubDate>Tue, 02 Jan 2024 03:04:05 +0000</pubDate><media:content url="1.jpg"/></item></channel></rss>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, err = mainWrapper(t, []string{"html2data", "-xml", filepath.Join(dir, "feed.xml"), ":date", "pubDate", ":image", "media|content:attr(url)"})
+	if err != nil || !(out == "date:\tTue, 02 Jan 2024 03:04:05 +0000\nimage:\t1.jpg" || out == "image:\t1.jpg\ndate:\tTue, 02 Jan 2024 03:04:05 +0000") {
+		t.Errorf("7.8. main() failed: got: '%s'", out)
+	}
+
+	// suggest selectors
+	out, err = mainWrapper(t, []string{"html2data", "suggest", "-json", "test.html", "Title"})
+	if err != nil || out != `[{"selector":"title","specificity":1,"matches":1,"values":["Title"]}]` {
+		t.Errorf("7.9. main() failed: got: '%s'", out)
+	}
+
 	// error in args
 	out, err = mainWrapper(t, []string{"html2data", "-find-in=div.article", "test.html", "h1", "h1"})
 	if err == nil {
 		t.Errorf("8. main() failed: got: '%s'", out)
 	}
 }
+
+func Test_printExplain(t *testing.T) {
+	doc := html2data.FromReaderCfg(strings.NewReader("<ul>\n<li>1</li><li>2</li></ul>"), html2data.ReaderCfg{KeepSource: true})
+	traces := []html2data.SelectorTrace{}
+	_, err := doc.GetDataNested("ul", map[string]string{"n": "li:get(2):int"}, html2data.Cfg{Trace: func(trace html2data.SelectorTrace) {
+		traces = append(traces, trace)
+	}})
+	if err != nil || len(traces) != 2 {
+		t.Fatalf("traces: got: %#v, %v", traces, err)
+	}
+
+	out := bytes.Buffer{}
+	for _, trace := range traces {
+		if err := printExplain(&out, trace); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := "find-in: ul\n" +
+		"  css: ul\n" +
+		"  matched: 1\n" +
+		"  [1] html > body > ul (line 1:1)\n" +
+		":n li:get(2):int\n" +
+		"  in: html > body > ul\n" +
+		"  css: li\n" +
+		"  pseudo: :get(2), :int\n" +
+		"  matched: 2, filtered out by :get(N): 1\n" +
+		"  [1] html > body > ul > li:nth-of-type(1) (line 2:1) - filtered out\n" +
+		"  [2] html > body > ul > li:nth-of-type(2) (line 2:11)\n" +
+		"      2\n"
+	if out.String() != expected {
+		t.Errorf("printExplain():\nexpected: %q\nreal:     %q", expected, out.String())
+	}
+}
+
+func Test_printSuggestions(t *testing.T) {
+	out := bytes.Buffer{}
+	err := printSuggestions(&out, []html2data.SelectorSuggestion{
+		{Selector: "li.item", Matches: 5, Values: []string{"1", "2", "3", "4", "5"}},
+		{Selector: "#title", Matches: 1, Values: []string{"Title"}},
+	})
+
+	expected := "1. li.item\n   5 matches: \"1\", \"2\", \"3\", ...\n" +
+		"2. #title\n   1 match: \"Title\"\n"
+	if err != nil || out.String() != expected {
+		t.Errorf("printSuggestions():\nexpected: %q\nreal:     %q", expected, out.String())
+	}
+}
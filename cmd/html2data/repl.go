@@ -0,0 +1,318 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/msoap/html2data"
+)
+
+const (
+	replPrompt      = "> "
+	replMaxMatches  = 20  // max count of printed matches for one selector
+	replMaxValueLen = 200 // max length of printed value in runes
+	replHistoryFile = ".html2data_history"
+)
+
+const replHelpString = `Type CSS selector with pseudo-selectors for show matched elements, or command:
+  /add name css    add selector to recipe
+  /del name        delete selector from recipe
+  /find-in css     search selectors of recipe in the specified elements (empty for reset)
+  /list            show recipe
+  /run             extract data by recipe, as JSON
+  /export [file]   save recipe as JSON to file (or print it), for "serve" requests
+  /history         show history of input
+  !N, !!           repeat N-th or last input from history
+  /help            show this help
+  /quit            exit (or Ctrl-D)`
+
+// repl - interactive session for trying selectors on loaded document
+type repl struct {
+	doc         html2data.Doc
+	out         io.Writer
+	recipe      recipe
+	history     []string
+	historyFile string // file for save history between sessions, empty - don't save
+	err         error  // the first error of write to out
+}
+
+// newREPL - create session, recipe is started from selectors of command line
+func newREPL(doc html2data.Doc, out io.Writer, start recipe, historyFile string) *repl {
+	session := &repl{
+		doc:         doc,
+		out:         out,
+		recipe:      recipe{FindIn: start.FindIn, Selectors: map[string]string{}},
+		historyFile: historyFile,
+	}
+	for name, selector := range start.Selectors {
+		session.recipe.Selectors[name] = selector
+	}
+	if historyFile != "" {
+		if content, err := os.ReadFile(historyFile); err == nil { // #nosec
+			session.history = strings.FieldsFunc(string(content), func(char rune) bool { return char == '\n' })
+		}
+	}
+
+	return session
+}
+
+// replHistoryPath - path of history file in home directory, empty if home directory is unknown
+func replHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, replHistoryFile)
+}
+
+// printf - print to output, the first error of write is saved and returned by run
+func (session *repl) printf(format string, args ...interface{}) {
+	if session.err == nil {
+		_, session.err = fmt.Fprintf(session.out, format, args...)
+	}
+}
+
+// run - read and execute lines from input until EOF or /quit, returns error of input or output
+func (session *repl) run(in io.Reader) error {
+	session.printf("Type CSS selector or /help, /quit for exit\n")
+	scanner := bufio.NewScanner(in)
+	for session.err == nil {
+		session.printf("%s", replPrompt)
+		if !scanner.Scan() {
+			session.printf("\n")
+			if err := scanner.Err(); err != nil {
+				return err
+			}
+			return session.err
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "!") {
+			var ok bool
+			if line, ok = session.fromHistory(line); !ok {
+				continue
+			}
+			session.printf("%s\n", line)
+		}
+		session.addHistory(line)
+
+		if quit := session.exec(line); quit {
+			return session.err
+		}
+	}
+
+	return session.err
+}
+
+// fromHistory - get line from history by "!N" or "!!"
+func (session *repl) fromHistory(line string) (string, bool) {
+	index := len(session.history)
+	if line != "!!" {
+		var err error
+		if index, err = strconv.Atoi(line[1:]); err != nil {
+			session.printf("use !N or !! for repeat input from history\n")
+			return "", false
+		}
+	}
+	if index < 1 || index > len(session.history) {
+		session.printf("history entry %s is not found\n", line)
+		return "", false
+	}
+
+	return session.history[index-1], true
+}
+
+// addHistory - add line to history and save it to history file
+func (session *repl) addHistory(line string) {
+	session.history = append(session.history, line)
+	if session.historyFile == "" {
+		return
+	}
+
+	file, err := os.OpenFile(session.historyFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		session.printf("save history: %s\n", err)
+		session.historyFile = ""
+		return
+	}
+	_, err = fmt.Fprintln(file, line)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		session.printf("save history: %s\n", err)
+	}
+}
+
+// exec - execute one line: command or selector, returns true for quit
+func (session *repl) exec(line string) (quit bool) {
+	if !strings.HasPrefix(line, "/") {
+		session.printMatches(line)
+		return false
+	}
+
+	command, args := line, ""
+	if i := strings.IndexAny(line, " \t"); i > 0 {
+		command, args = line[:i], strings.TrimSpace(line[i+1:])
+	}
+
+	var err error
+	switch command {
+	case "/quit", "/exit":
+		return true
+	case "/help":
+		session.printf("%s\n", replHelpString)
+	case "/add":
+		name, selector := args, ""
+		if i := strings.IndexAny(args, " \t"); i > 0 {
+			name, selector = args[:i], strings.TrimSpace(args[i+1:])
+		}
+		if name == "" || selector == "" {
+			session.printf("usage: /add name css\n")
+			return false
+		}
+		session.recipe.Selectors[strings.TrimPrefix(name, ":")] = selector
+		session.printMatches(selector)
+	case "/del":
+		name := strings.TrimPrefix(args, ":")
+		if _, ok := session.recipe.Selectors[name]; !ok {
+			session.printf("selector '%s' is not found\n", name)
+			return false
+		}
+		delete(session.recipe.Selectors, name)
+	case "/find-in":
+		session.recipe.FindIn = args
+	case "/list":
+		session.printRecipe()
+	case "/run":
+		err = session.runRecipe()
+	case "/export":
+		err = session.exportRecipe(args)
+	case "/history":
+		for i, item := range session.history {
+			session.printf("%4d  %s\n", i+1, item)
+		}
+	default:
+		session.printf("unknown command: %s, see /help\n", command)
+	}
+
+	if err != nil {
+		session.printf("error: %s\n", err)
+	}
+
+	return false
+}
+
+// printMatches - print count, DOM paths and values of elements matched by selector
+func (session *repl) printMatches(selector string) {
+	matches, err := session.doc.Matches(selector, html2data.Cfg{DontTrimSpaces: config.dontTrimSpaces})
+	if err != nil {
+		session.printf("error: %s\n", err)
+		return
+	}
+
+	switch len(matches) {
+	case 1:
+		session.printf("1 match\n")
+	default:
+		session.printf("%d matches\n", len(matches))
+	}
+
+	for i, match := range matches {
+		if i == replMaxMatches {
+			session.printf("... and %d more\n", len(matches)-replMaxMatches)
+			break
+		}
+		session.printf("[%d] %s\n", i+1, match.Path)
+		for _, value := range match.Values {
+			session.printf("    %s\n", replValue(value))
+		}
+	}
+}
+
+// replValue - format value for print: strings are quoted and shortened
+func replValue(value interface{}) string {
+	text, ok := value.(string)
+	switch {
+	case value == nil:
+		return "null"
+	case !ok:
+		return fmt.Sprintf("%v", value)
+	}
+
+	if utf8.RuneCountInString(text) > replMaxValueLen {
+		text = string([]rune(text)[:replMaxValueLen]) + "..."
+	}
+
+	return strconv.Quote(text)
+}
+
+// printRecipe - print selectors of recipe sorted by name
+func (session *repl) printRecipe() {
+	if session.recipe.FindIn != "" {
+		session.printf("find-in: %s\n", session.recipe.FindIn)
+	}
+	if len(session.recipe.Selectors) == 0 {
+		session.printf("recipe is empty, use /add name css\n")
+		return
+	}
+
+	names := make([]string, 0, len(session.recipe.Selectors))
+	for name := range session.recipe.Selectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		session.printf(":%s %s\n", name, session.recipe.Selectors[name])
+	}
+}
+
+// runRecipe - extract data by all selectors of recipe
+func (session *repl) runRecipe() (err error) {
+	var result interface{}
+	cfg := html2data.Cfg{DontTrimSpaces: config.dontTrimSpaces}
+	if session.recipe.FindIn != "" {
+		result, err = session.doc.GetDataNestedTyped(session.recipe.FindIn, session.recipe.Selectors, cfg)
+	} else {
+		result, err = session.doc.GetDataTyped(session.recipe.Selectors, cfg)
+	}
+	if err != nil {
+		return err
+	}
+
+	return printJSON(session.out, result)
+}
+
+// exportRecipe - write recipe as JSON to file or to output
+func (session *repl) exportRecipe(fileName string) error {
+	jsonBytes, err := json.MarshalIndent(session.recipe, "", "  ")
+	if err != nil {
+		return err
+	}
+	jsonBytes = append(jsonBytes, '\n')
+
+	if fileName == "" {
+		_, err = session.out.Write(jsonBytes)
+		return err
+	}
+
+	if err := os.WriteFile(fileName, jsonBytes, 0o644); err != nil { // #nosec
+		return err
+	}
+	session.printf("recipe saved to %s\n", fileName)
+
+	return nil
+}
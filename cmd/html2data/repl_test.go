@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/msoap/html2data"
+)
+
+func Test_repl(t *testing.T) {
+	doc := html2data.FromReader(strings.NewReader(`<ul id="list"><li class="item">one</li><li class="item">2</li></ul>`))
+	dir := t.TempDir()
+	historyFile := filepath.Join(dir, "history")
+	recipeFile := filepath.Join(dir, "recipe.json")
+
+	const (
+		li1 = "[1] html > body > ul#list > li.item:nth-of-type(1)\n"
+		li2 = "[2] html > body > ul#list > li.item:nth-of-type(2)\n"
+	)
+
+	testData := []struct {
+		input       string
+		historyFile string
+		out         string
+	}{
+		{
+			input: "li\n",
+			out:   "2 matches\n" + li1 + "    \"one\"\n" + li2 + "    \"2\"\n",
+		},
+		{
+			input: "li:get(2):int\n",
+			out:   "1 match\n[1] html > body > ul#list > li.item:nth-of-type(2)\n    2\n",
+		},
+		{
+			input: "li:int\n",
+			out:   "2 matches\n" + li1 + "    null\n" + li2 + "    2\n",
+		},
+		{
+			input: "h1\n",
+			out:   "0 matches\n",
+		},
+		{
+			input: "/add :first li:get(1)\n/add\n/list\n",
+			out:   "1 match\n" + li1 + "    \"one\"\nusage: /add name css\n:first li:get(1)\n",
+		},
+		{
+			input: "/add first li:get(1)\n/add all li\n/run\n",
+			out: "1 match\n" + li1 + "    \"one\"\n" +
+				"2 matches\n" + li1 + "    \"one\"\n" + li2 + "    \"2\"\n" +
+				`{"all":["one","2"],"first":["one"]}` + "\n",
+		},
+		{
+			input: "/add n h1\n/del n\n/del n\n/list\n",
+			out:   "0 matches\nselector 'n' is not found\nrecipe is empty, use /add name css\n",
+		},
+		{
+			input: "/find-in ul\n/add items li\n/export\n",
+			out: "2 matches\n" + li1 + "    \"one\"\n" + li2 + "    \"2\"\n" +
+				"{\n  \"find_in\": \"ul\",\n  \"selectors\": {\n    \"items\": \"li\"\n  }\n}\n",
+		},
+		{
+			input:       "h1\n!!\n!1\n!9\n!x\n/history\n",
+			historyFile: historyFile,
+			out: "0 matches\n" +
+				"h1\n0 matches\n" +
+				"h1\n0 matches\n" +
+				"history entry !9 is not found\n" +
+				"use !N or !! for repeat input from history\n" +
+				"   1  h1\n   2  h1\n   3  h1\n   4  /history\n",
+		},
+		{
+			input: "/unknown\n/quit\nli\n",
+			out:   "unknown command: /unknown, see /help\n",
+		},
+		{
+			input: "/add title li:get(1)\n/export " + recipeFile + "\n",
+			out:   "1 match\n" + li1 + "    \"one\"\nrecipe saved to " + recipeFile + "\n",
+		},
+	}
+
+	for i, item := range testData {
+		out := bytes.Buffer{}
+		if err := newREPL(doc, &out, recipe{}, item.historyFile).run(strings.NewReader(item.input)); err != nil {
+			t.Errorf("%d. repl failed: %s", i, err)
+			continue
+		}
+
+		// without greeting and prompts
+		real := strings.TrimPrefix(out.String(), "Type CSS selector or /help, /quit for exit\n")
+		real = "\n" + real
+		for strings.Contains(real, "\n"+replPrompt) {
+			real = strings.ReplaceAll(real, "\n"+replPrompt, "\n")
+		}
+		real = strings.TrimRight(real[1:], "\n")
+		if real != strings.TrimRight(item.out, "\n") {
+			t.Errorf("%d. repl(%q):\nexpected: %q\nreal:     %q", i, item.input, item.out, real)
+		}
+	}
+
+	history, err := os.ReadFile(historyFile)
+	if err != nil || string(history) != "h1\nh1\nh1\n/history\n" {
+		t.Errorf("history file: got: %q, %v", history, err)
+	}
+	if session := newREPL(doc, &bytes.Buffer{}, recipe{}, historyFile); len(session.history) != 4 {
+		t.Errorf("history is not loaded: %q", session.history)
+	}
+
+	out := bytes.Buffer{}
+	if err := newREPL(doc, &out, recipe{FindIn: "ul", Selectors: map[string]string{"b": "li:get(2)", "a": "li"}}, "").run(strings.NewReader("/list\n")); err != nil ||
+		!strings.Contains(out.String(), "find-in: ul\n:a li\n:b li:get(2)\n") {
+		t.Errorf("recipe from command line: got: %q, %v", out.String(), err)
+	}
+
+	// error of output stops session
+	closedOut, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := closedOut.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := newREPL(doc, closedOut, recipe{}, "").run(strings.NewReader("h1\nh1\n")); err == nil {
+		t.Errorf("run() with closed output: error expected")
+	}
+
+	recipeJSON, err := os.ReadFile(recipeFile)
+	if err != nil || !strings.Contains(string(recipeJSON), `"title": "li:get(1)"`) {
+		t.Errorf("exported recipe: got: %s, %v", recipeJSON, err)
+	}
+}
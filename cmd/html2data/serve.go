@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/msoap/html2data"
+)
+
+const serveUsageString = "Usage:\n" +
+	"  html2data serve [-listen :8080] [options]\n\n" +
+	"  POST /extract {\"html\": \"...\" or \"url\": \"...\", \"selectors\": {\"name\": \"css\"}, \"find_in\": \"css\"}\n\n" +
+	"options:"
+
+// recipe - selectors for extract data, with optional outer selector (like -find-in)
+type recipe struct {
+	FindIn    string            `json:"find_in,omitempty"`
+	Selectors map[string]string `json:"selectors"`
+}
+
+// extractRequest - body of POST /extract
+type extractRequest struct {
+	HTML string `json:"html,omitempty"`
+	URL  string `json:"url,omitempty"`
+	XML  bool   `json:"xml,omitempty"`
+	recipe
+}
+
+// extractResponse - result of POST /extract
+type extractResponse struct {
+	URL  string      `json:"url,omitempty"`
+	Data interface{} `json:"data"`
+}
+
+// serveError - structured error of extraction server
+type serveError struct {
+	status  int
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// serveConfig - config of extraction server
+type serveConfig struct {
+	listen       string
+	maxBodySize  int64
+	maxFetchSize int64
+	timeout      time.Duration
+	fetchTimeout int
+	userAgent    string
+	noFetch      bool
+	allowPrivate bool
+}
+
+// runServe - run extraction server, args are arguments after "serve" command
+func runServe(args []string) error {
+	config := serveConfig{}
+	flagSet := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flagSet.Usage = func() {
+		_, _ = fmt.Fprintln(flagSet.Output(), serveUsageString)
+		flagSet.PrintDefaults()
+	}
+	flagSet.StringVar(&config.listen, "listen", ":8080", "listen `address`")
+	flagSet.Int64Var(&config.maxBodySize, "max-body-size", 10<<20, "max size of request body in `bytes`")
+	flagSet.Int64Var(&config.maxFetchSize, "max-fetch-size", 10<<20, "max size of fetched page in `bytes`")
+	flagSet.DurationVar(&config.timeout, "timeout", 30*time.Second, "timeout of request `duration` (fetch URL and extract)")
+	flagSet.IntVar(&config.fetchTimeout, "fetch-timeout", 10, "timeout of fetch URL in `seconds`")
+	flagSet.StringVar(&config.userAgent, "user-agent", "", "set custom user-agent for fetch URL")
+	flagSet.BoolVar(&config.noFetch, "no-fetch", false, "don't fetch URLs, accept only HTML in request")
+	flagSet.BoolVar(&config.allowPrivate, "allow-private", false, "allow fetch URLs in loopback, private and link-local networks")
+	if err := flagSet.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			// usage is printed, it is not an error
+			return nil
+		}
+		return err
+	}
+
+	server := &http.Server{
+		Addr:              config.listen,
+		Handler:           newServeHandler(config),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       config.timeout,
+		WriteTimeout:      config.timeout + 10*time.Second,
+	}
+	log.Printf("listen on %s", config.listen)
+
+	return server.ListenAndServe()
+}
+
+// newServeHandler - handler of extraction server
+func newServeHandler(config serveConfig) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/extract", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeServeError(w, serveError{status: http.StatusMethodNotAllowed, Code: "method_not_allowed", Message: "only POST method is allowed"})
+			return
+		}
+
+		request, errServe := readExtractRequest(r, config)
+		if errServe != nil {
+			writeServeError(w, *errServe)
+			return
+		}
+
+		// fetch of URL is cancelled on timeout or when client is gone
+		ctx, cancel := context.WithTimeout(r.Context(), config.timeout)
+		defer cancel()
+
+		type result struct {
+			response extractResponse
+			err      *serveError
+		}
+		done := make(chan result, 1)
+		go func() {
+			response, err := extract(ctx, request, config)
+			done <- result{response: response, err: err}
+		}()
+
+		select {
+		case result := <-done:
+			if result.err != nil {
+				writeServeError(w, *result.err)
+				return
+			}
+			writeServeJSON(w, http.StatusOK, result.response)
+		case <-ctx.Done():
+			writeServeError(w, serveError{status: http.StatusGatewayTimeout, Code: "timeout", Message: "extraction timed out after " + config.timeout.String()})
+		}
+	})
+
+	return mux
+}
+
+// readExtractRequest - read and validate body of request
+func readExtractRequest(r *http.Request, config serveConfig) (request extractRequest, errServe *serveError) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, config.maxBodySize+1))
+	if err != nil {
+		return request, &serveError{status: http.StatusBadRequest, Code: "bad_request", Message: err.Error()}
+	}
+	if int64(len(body)) > config.maxBodySize {
+		return request, &serveError{status: http.StatusRequestEntityTooLarge, Code: "body_too_large", Message: fmt.Sprintf("request body is larger than %d bytes", config.maxBodySize)}
+	}
+
+	if err := json.Unmarshal(body, &request); err != nil {
+		return request, &serveError{status: http.StatusBadRequest, Code: "invalid_json", Message: err.Error()}
+	}
+
+	switch {
+	case request.HTML == "" && request.URL == "":
+		return request, &serveError{status: http.StatusBadRequest, Code: "invalid_request", Message: `"html" or "url" is required`}
+	case request.HTML != "" && request.URL != "":
+		return request, &serveError{status: http.StatusBadRequest, Code: "invalid_request", Message: `only one of "html" or "url" is allowed`}
+	case request.URL != "" && config.noFetch:
+		return request, &serveError{status: http.StatusBadRequest, Code: "fetch_disabled", Message: "fetching URLs is disabled"}
+	case request.URL != "" && !isURL(request.URL):
+		return request, &serveError{status: http.StatusBadRequest, Code: "invalid_request", Message: `"url" must be http(s) URL`}
+	case len(request.Selectors) == 0:
+		return request, &serveError{status: http.StatusBadRequest, Code: "invalid_request", Message: `"selectors" is required`}
+	}
+
+	return request, nil
+}
+
+// extract - load document and extract typed data by recipe
+func extract(ctx context.Context, request extractRequest, config serveConfig) (response extractResponse, errServe *serveError) {
+	var doc html2data.Doc
+	switch {
+	case request.URL != "":
+		doc = html2data.FromURLContext(ctx, request.URL, html2data.URLCfg{
+			UA:                  config.userAgent,
+			TimeOut:             config.fetchTimeout,
+			XML:                 request.XML,
+			DenyPrivateNetworks: !config.allowPrivate,
+			MaxBodySize:         config.maxFetchSize,
+		})
+		if errors.Is(doc.Err, html2data.ErrPrivateAddress) {
+			return response, &serveError{status: http.StatusForbidden, Code: "forbidden_address", Message: doc.Err.Error()}
+		}
+		if errors.Is(doc.Err, html2data.ErrBodyTooLarge) {
+			return response, &serveError{status: http.StatusBadGateway, Code: "response_too_large", Message: doc.Err.Error()}
+		}
+		if doc.Err != nil {
+			return response, &serveError{status: http.StatusBadGateway, Code: "fetch_error", Message: doc.Err.Error()}
+		}
+	case request.XML:
+		doc = html2data.FromReaderXML(strings.NewReader(request.HTML))
+	default:
+		doc = html2data.FromReader(strings.NewReader(request.HTML))
+	}
+	if doc.Err != nil {
+		return response, &serveError{status: http.StatusUnprocessableEntity, Code: "parse_error", Message: doc.Err.Error()}
+	}
+
+	response.URL = doc.URL
+	var err error
+	if request.FindIn != "" {
+		response.Data, err = doc.GetDataNestedTyped(request.FindIn, request.Selectors)
+	} else {
+		response.Data, err = doc.GetDataTyped(request.Selectors)
+	}
+	if err != nil {
+		return response, &serveError{status: http.StatusUnprocessableEntity, Code: "extract_error", Message: err.Error()}
+	}
+
+	return response, nil
+}
+
+// writeServeError - write structured error: {"error": {"code": "...", "message": "..."}}
+func writeServeError(w http.ResponseWriter, errServe serveError) {
+	writeServeJSON(w, errServe.status, map[string]serveError{"error": errServe})
+}
+
+// writeServeJSON - write value as JSON response
+func writeServeJSON(w http.ResponseWriter, status int, value interface{}) {
+	buf := bytes.Buffer{}
+	if err := json.NewEncoder(&buf).Encode(value); err != nil {
+		status, buf = http.StatusInternalServerError, bytes.Buffer{}
+		_ = json.NewEncoder(&buf).Encode(map[string]serveError{"error": {Code: "internal_error", Message: err.Error()}})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Printf("write response: %s", err)
+	}
+}
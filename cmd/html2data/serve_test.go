@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_serveHandler(t *testing.T) {
+	pages := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `<title>Remote</title>`)
+	}))
+	defer pages.Close()
+
+	ts := httptest.NewServer(newServeHandler(serveConfig{maxBodySize: 200, timeout: 5 * time.Second, fetchTimeout: 5, allowPrivate: true}))
+	defer ts.Close()
+
+	testData := []struct {
+		method string
+		body   string
+		status int
+		out    string
+	}{
+		{
+			method: "POST",
+			body:   `{"html": "<h1>Head</h1><b>1,5</b>", "selectors": {"head": "h1", "num": "b:number(,)"}}`,
+			status: http.StatusOK,
+			out:    `{"data":{"head":["Head"],"num":[1.5]}}`,
+		},
+		{
+			method: "POST",
+			body:   `{"html": "<li><a>1</a></li><li><a>2</a></li>", "find_in": "li", "selectors": {"n": "a:int"}}`,
+			status: http.StatusOK,
+			out:    `{"data":[{"n":[1]},{"n":[2]}]}`,
+		},
+		{
+			method: "POST",
+			body:   `{"url": "` + pages.URL + `", "selectors": {"title": "title"}}`,
+			status: http.StatusOK,
+			out:    `{"url":"` + pages.URL + `","data":{"title":["Remote"]}}`,
+		},
+		{
+			method: "POST",
+			body:   `{"html": "<rss><pubDate>today</pubDate></rss>", "xml": true, "selectors": {"date": "pubDate"}}`,
+			status: http.StatusOK,
+			out:    `{"data":{"date":["today"]}}`,
+		},
+		{
+			method: "GET",
+			status: http.StatusMethodNotAllowed,
+			out:    `{"error":{"code":"method_not_allowed","message":"only POST method is allowed"}}`,
+		},
+		{
+			method: "POST",
+			body:   `{"html": "` + strings.Repeat("x", 200) + `"}`,
+			status: http.StatusRequestEntityTooLarge,
+			out:    `{"error":{"code":"body_too_large","message":"request body is larger than 200 bytes"}}`,
+		},
+		{
+			method: "POST",
+			body:   `{"html": `,
+			status: http.StatusBadRequest,
+			out:    `{"error":{"code":"invalid_json","message":"unexpected end of JSON input"}}`,
+		},
+		{
+			method: "POST",
+			body:   `{"selectors": {"a": "a"}}`,
+			status: http.StatusBadRequest,
+			out:    `{"error":{"code":"invalid_request","message":"\"html\" or \"url\" is required"}}`,
+		},
+		{
+			method: "POST",
+			body:   `{"html": "<a>"}`,
+			status: http.StatusBadRequest,
+			out:    `{"error":{"code":"invalid_request","message":"\"selectors\" is required"}}`,
+		},
+		{
+			method: "POST",
+			body:   `{"url": "file:///etc/passwd", "selectors": {"a": "a"}}`,
+			status: http.StatusBadRequest,
+			out:    `{"error":{"code":"invalid_request","message":"\"url\" must be http(s) URL"}}`,
+		},
+		{
+			method: "POST",
+			body:   `{"url": "http://127.0.0.1:1/", "selectors": {"a": "a"}}`,
+			status: http.StatusBadGateway,
+		},
+	}
+
+	for i, item := range testData {
+		request, err := http.NewRequest(item.method, ts.URL+"/extract", strings.NewReader(item.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(response.Body)
+		if errClose := response.Body.Close(); err == nil {
+			err = errClose
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := strings.TrimSpace(string(body))
+		if response.StatusCode != item.status || (item.out != "" && out != item.out) || response.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("%d. POST /extract: expected: %d %s\nreal: %d %s", i, item.status, item.out, response.StatusCode, out)
+		}
+	}
+
+	noFetch := httptest.NewServer(newServeHandler(serveConfig{maxBodySize: 1000, timeout: time.Second, noFetch: true}))
+	defer noFetch.Close()
+	response, err := http.Post(noFetch.URL+"/extract", "application/json", strings.NewReader(`{"url": "`+pages.URL+`", "selectors": {"a": "a"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := response.Body.Close(); err != nil || response.StatusCode != http.StatusBadRequest {
+		t.Errorf("POST /extract with -no-fetch: got: %d, %v", response.StatusCode, err)
+	}
+
+	// fetched page is larger than -max-fetch-size
+	smallFetch := httptest.NewServer(newServeHandler(serveConfig{maxBodySize: 1000, maxFetchSize: 10, timeout: time.Second, fetchTimeout: 1, allowPrivate: true}))
+	defer smallFetch.Close()
+	response, err = http.Post(smallFetch.URL+"/extract", "application/json", strings.NewReader(`{"url": "`+pages.URL+`", "selectors": {"a": "a"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(response.Body)
+	if errClose := response.Body.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil || response.StatusCode != http.StatusBadGateway || !strings.Contains(string(body), `"response_too_large"`) {
+		t.Errorf("POST /extract with -max-fetch-size: got: %d %s, %v", response.StatusCode, body, err)
+	}
+
+	// loopback address of test server is not allowed without -allow-private
+	denyPrivate := httptest.NewServer(newServeHandler(serveConfig{maxBodySize: 1000, timeout: time.Second, fetchTimeout: 1}))
+	defer denyPrivate.Close()
+	for _, url := range []string{pages.URL, strings.Replace(pages.URL, "127.0.0.1", "localhost", 1)} {
+		response, err = http.Post(denyPrivate.URL+"/extract", "application/json", strings.NewReader(`{"url": "`+url+`", "selectors": {"a": "a"}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := response.Body.Close(); err != nil || response.StatusCode != http.StatusForbidden {
+			t.Errorf("POST /extract with private address %s: got: %d, %v", url, response.StatusCode, err)
+		}
+	}
+}
+
+func Test_serveHandlerTimeout(t *testing.T) {
+	cancelled := make(chan struct{})
+	slowPage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			close(cancelled)
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer slowPage.Close()
+
+	ts := httptest.NewServer(newServeHandler(serveConfig{maxBodySize: 1000, timeout: 100 * time.Millisecond, fetchTimeout: 10, allowPrivate: true}))
+	defer ts.Close()
+
+	response, err := http.Post(ts.URL+"/extract", "application/json", strings.NewReader(`{"url": "`+slowPage.URL+`", "selectors": {"a": "a"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := response.Body.Close(); err != nil || response.StatusCode != http.StatusGatewayTimeout {
+		t.Errorf("POST /extract with slow page: got: %d, %v", response.StatusCode, err)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Errorf("fetch of slow page is not cancelled after timeout")
+	}
+}
+
+func Test_runServeHelp(t *testing.T) {
+	if err := runServe([]string{"-help"}); err != nil {
+		t.Errorf("runServe(-help): expected no error, got: %v", err)
+	}
+	if err := runServe([]string{"-unknown-flag"}); err == nil {
+		t.Errorf("runServe(-unknown-flag): error expected")
+	}
+}
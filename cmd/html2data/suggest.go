@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/msoap/html2data"
+)
+
+const (
+	suggestUsageString = "Usage:\n" +
+		"  html2data suggest [options] url|file 'example text'\n\n" +
+		"options:"
+	suggestMaxValues = 3 // max count of printed values for each selector
+)
+
+// runSuggest - print selectors for elements with example text, args are arguments after "suggest" command
+func runSuggest(args []string) error {
+	var getJSON bool
+	flagSet := flag.NewFlagSet("suggest", flag.ContinueOnError)
+	flagSet.Usage = func() {
+		_, _ = fmt.Fprintln(flagSet.Output(), suggestUsageString)
+		flagSet.PrintDefaults()
+	}
+	flagSet.StringVar(&config.userAgent, "user-agent", "", "set custom user-agent")
+	flagSet.IntVar(&config.timeOut, "timeout", 0, "timeout in `seconds`")
+	flagSet.BoolVar(&config.xml, "xml", false, "parse input as XML")
+	flagSet.BoolVar(&getJSON, "json", false, "JSON output")
+	if err := flagSet.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flagSet.NArg() != 2 {
+		flagSet.Usage()
+		return fmt.Errorf("url or file and example text are required")
+	}
+
+	doc := loadDoc(flagSet.Arg(0))
+	suggestions, err := html2data.SuggestSelectors(doc, flagSet.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	if getJSON {
+		return printJSON(os.Stdout, suggestions)
+	}
+
+	return printSuggestions(os.Stdout, suggestions)
+}
+
+// printSuggestions - print suggested selectors with count of found elements and first values
+func printSuggestions(out io.Writer, suggestions []html2data.SelectorSuggestion) error {
+	buf := bytes.Buffer{}
+	for i, suggestion := range suggestions {
+		values := []string{}
+		for _, value := range suggestion.Values {
+			if len(values) == suggestMaxValues {
+				values = append(values, "...")
+				break
+			}
+			values = append(values, replValue(value))
+		}
+
+		matches := "matches"
+		if suggestion.Matches == 1 {
+			matches = "match"
+		}
+		fmt.Fprintf(&buf, "%d. %s\n   %d %s: %s\n", i+1, suggestion.Selector, suggestion.Matches, matches, strings.Join(values, ", "))
+	}
+
+	_, err := out.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// templateValue - values of one selector in template, prints as values joined by space: {{.title}}
+type templateValue []string
+
+func (value templateValue) String() string {
+	return strings.Join(value, " ")
+}
+
+// templateFuncs - helper functions for -template
+var templateFuncs = template.FuncMap{
+	// first value: {{first .links}}
+	"first": func(value interface{}) string {
+		if list := toStrings(value); len(list) > 0 {
+			return list[0]
+		}
+		return ""
+	},
+	// join values with separator: {{.tags | join ", "}}
+	"join": func(separator string, value interface{}) string {
+		return strings.Join(toStrings(value), separator)
+	},
+	// default value for empty value: {{.price | default "n/a"}}
+	"default": func(defaultValue string, value interface{}) string {
+		if text := strings.Join(toStrings(value), " "); text != "" {
+			return text
+		}
+		return defaultValue
+	},
+	// trim spaces: {{trim .title}}
+	"trim": func(value interface{}) string {
+		return strings.TrimSpace(strings.Join(toStrings(value), " "))
+	},
+}
+
+// toStrings - convert template argument to list of strings
+func toStrings(value interface{}) []string {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case templateValue:
+		return value
+	case []string:
+		return value
+	case string:
+		return []string{value}
+	default:
+		return []string{fmt.Sprint(value)}
+	}
+}
+
+// parseTemplate - parse template from -template option or from -template-file
+func parseTemplate(text, fileName string) (*template.Template, error) {
+	if fileName != "" {
+		content, err := os.ReadFile(fileName) // #nosec
+		if err != nil {
+			return nil, err
+		}
+		text = string(content)
+	}
+
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
+	return template.New("output").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+}
+
+// templateData - data of one result for template
+func templateData(texts map[string][]string) map[string]templateValue {
+	result := make(map[string]templateValue, len(texts))
+	for name, values := range texts {
+		result[name] = values
+	}
+
+	return result
+}
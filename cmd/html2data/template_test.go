@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_parseTemplate(t *testing.T) {
+	testData := []struct {
+		template string
+		out      string
+	}{
+		{`{{.title}}`, "Title\n"},
+		{`{{.links}}`, "url1 url2\n"},
+		{`{{first .links}}|{{first .empty}}`, "url1|\n"},
+		{`{{.links | join ", "}}`, "url1, url2\n"},
+		{`{{.empty | default "n/a"}} {{.title | default "n/a"}}`, "n/a Title\n"},
+		{`{{trim .spaces}}|{{range .links}}<{{.}}>{{end}}` + "\n", "text|<url1><url2>\n"},
+	}
+
+	data := templateData(map[string][]string{
+		"title":  {"Title"},
+		"links":  {"url1", "url2"},
+		"empty":  {},
+		"spaces": {"  text "},
+	})
+
+	for i, item := range testData {
+		tmpl, err := parseTemplate(item.template, "")
+		if err != nil {
+			t.Errorf("%d. parseTemplate() got error: %s", i, err)
+			continue
+		}
+
+		out := bytes.Buffer{}
+		if err := tmpl.Execute(&out, data); err != nil || out.String() != item.out {
+			t.Errorf("%d. template %q\nexpected: %q\nreal    : %q, %v", i, item.template, item.out, out.String(), err)
+		}
+	}
+
+	if _, err := parseTemplate("{{.title", ""); err == nil {
+		t.Errorf("parseTemplate() with invalid template without error")
+	}
+	if _, err := parseTemplate("", "/dont exists file"); err == nil {
+		t.Errorf("parseTemplate() with not exists file without error")
+	}
+}
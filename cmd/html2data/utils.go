@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -19,43 +22,131 @@ Args variants:
 	http://url :name css :name css
 	file :name css :name css
 	:name css :name css
+
+	http://url1 file2 glob* css
+	http://url1 file2 glob* :name css :name css
 */
 
-func parseArgs(args []string) (url string, selectors map[string]string, err error) {
-	var tail []string
-	selectors = map[string]string{}
+func parseArgs(args []string) (sources []string, selectors map[string]string, err error) {
+	if len(args) == 0 {
+		return nil, nil, fmt.Errorf("arguments is empty")
+	}
 
+	selectors = map[string]string{}
 	switch {
-	case len(args) == 0:
-		return "", nil, fmt.Errorf("arguments is empty")
 	case len(args) == 1:
 		selectors["one"] = args[0]
-		url = "-"
-		return url, selectors, err
-	case len(args) == 2 && strings.HasPrefix(args[0], ":"):
-		selectors[strings.TrimLeft(args[0], ":")] = args[1]
-		url = "-"
+		return parseSourcesArgs(nil), selectors, nil
 	case len(args) == 2 && !strings.HasPrefix(args[0], ":"):
+		// source and one selector, which can begin from ":" (":root")
 		selectors["one"] = args[1]
-		url = args[0]
-	case len(args)%2 == 0:
-		// even arguments
-		url = "-"
-		tail = args[:]
-	case len(args)%2 != 0:
-		// not even arguments
-		url = args[0]
-		tail = args[1:]
-	}
-
-	for i := 0; i < len(tail); i += 2 {
-		name := tail[i]
-		if !strings.HasPrefix(name, ":") {
-			return "", nil, fmt.Errorf("name '%s' is not valid, must begin from ':'", name)
+		return parseSourcesArgs(args[:1]), selectors, nil
+	}
+
+	firstName := len(args)
+	for i, arg := range args {
+		if strings.HasPrefix(arg, ":") {
+			firstName = i
+			break
+		}
+	}
+
+	if firstName == len(args) {
+		selectors["one"] = args[len(args)-1]
+		sources = args[:len(args)-1]
+	} else {
+		sources = args[:firstName]
+		tail := args[firstName:]
+		for i := 0; i < len(tail); i += 2 {
+			name := tail[i]
+			if !strings.HasPrefix(name, ":") {
+				return nil, nil, fmt.Errorf("name '%s' is not valid, must begin from ':'", name)
+			}
+			if i+1 >= len(tail) {
+				return nil, nil, fmt.Errorf("selector for name '%s' is empty", name)
+			}
+			selectors[strings.TrimLeft(name, ":")] = tail[i+1]
+		}
+	}
+
+	return parseSourcesArgs(sources), selectors, nil
+}
+
+// parseSourcesArgs - parse arguments for modes without selectors: [url|file|-] ...
+func parseSourcesArgs(args []string) []string {
+	if len(args) == 0 {
+		return []string{"-"}
+	}
+
+	return args
+}
+
+// readInputList - read list of sources from file or stdin ("-"), one per line,
+// empty lines and lines beginning from "#" are skipped
+func readInputList(fileName string) (sources []string, err error) {
+	var content []byte
+	if fileName == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(fileName) // #nosec
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			sources = append(sources, line)
+		}
+	}
+
+	return sources, nil
+}
+
+// expandGlobs - expand file name patterns in sources, patterns without matches are kept as is
+func expandGlobs(sources []string) (result []string) {
+	for _, source := range sources {
+		if isURL(source) || !strings.ContainsAny(source, "*?[") {
+			result = append(result, source)
+			continue
 		}
-		name = strings.TrimLeft(name, ":")
-		selectors[name] = tail[i+1]
+
+		files, err := filepath.Glob(source)
+		if err != nil || len(files) == 0 {
+			result = append(result, source)
+			continue
+		}
+		result = append(result, files...)
 	}
 
-	return url, selectors, err
+	return result
+}
+
+// isURL - check that source is http(s) URL
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// isMIMEFile - check that file is saved web page (.mhtml) or email (.eml) by extension
+func isMIMEFile(fileName string) bool {
+	switch strings.ToLower(filepath.Ext(strings.TrimSuffix(fileName, ".gz"))) {
+	case ".mhtml", ".mht", ".eml":
+		return true
+	default:
+		return false
+	}
+}
+
+// stringsFlag - repeated string flag
+type stringsFlag []string
+
+func (values *stringsFlag) String() string {
+	return strings.Join(*values, ", ")
+}
+
+// Set - add value of flag, called for each flag in command line
+func (values *stringsFlag) Set(value string) error {
+	*values = append(*values, value)
+	return nil
 }
@@ -2,13 +2,14 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
 
-// func parseArgs(args []string) (url string, selectors map[string]string) {
 type parseArgsResult struct {
-	url       string
+	sources   []string
 	selectors map[string]string
 	err       string
 }
@@ -21,7 +22,7 @@ func Test_parseArgs(t *testing.T) {
 		{
 			in: []string{},
 			out: parseArgsResult{
-				url:       "",
+				sources:   nil,
 				selectors: nil,
 				err:       "arguments is empty",
 			},
@@ -29,7 +30,7 @@ func Test_parseArgs(t *testing.T) {
 		{
 			in: []string{"div"},
 			out: parseArgsResult{
-				url:       "-",
+				sources:   []string{"-"},
 				selectors: map[string]string{"one": "div"},
 				err:       "",
 			},
@@ -37,7 +38,7 @@ func Test_parseArgs(t *testing.T) {
 		{
 			in: []string{":name", "div"},
 			out: parseArgsResult{
-				url:       "-",
+				sources:   []string{"-"},
 				selectors: map[string]string{"name": "div"},
 				err:       "",
 			},
@@ -45,15 +46,23 @@ func Test_parseArgs(t *testing.T) {
 		{
 			in: []string{"http://url", ":name", "div"},
 			out: parseArgsResult{
-				url:       "http://url",
+				sources:   []string{"http://url"},
 				selectors: map[string]string{"name": "div"},
 				err:       "",
 			},
 		},
+		{
+			in: []string{"file.html", ":root"},
+			out: parseArgsResult{
+				sources:   []string{"file.html"},
+				selectors: map[string]string{"one": ":root"},
+				err:       "",
+			},
+		},
 		{
 			in: []string{"http://url", "div"},
 			out: parseArgsResult{
-				url:       "http://url",
+				sources:   []string{"http://url"},
 				selectors: map[string]string{"one": "div"},
 				err:       "",
 			},
@@ -61,7 +70,7 @@ func Test_parseArgs(t *testing.T) {
 		{
 			in: []string{":name1", "div1", ":name2", "div2"},
 			out: parseArgsResult{
-				url:       "-",
+				sources:   []string{"-"},
 				selectors: map[string]string{"name1": "div1", "name2": "div2"},
 				err:       "",
 			},
@@ -69,7 +78,7 @@ func Test_parseArgs(t *testing.T) {
 		{
 			in: []string{"file", ":name1", "div1", ":name2", "div2"},
 			out: parseArgsResult{
-				url:       "file",
+				sources:   []string{"file"},
 				selectors: map[string]string{"name1": "div1", "name2": "div2"},
 				err:       "",
 			},
@@ -77,24 +86,113 @@ func Test_parseArgs(t *testing.T) {
 		{
 			in: []string{"file", ":name1", "div1", "name2", "div2"},
 			out: parseArgsResult{
-				url:       "",
+				sources:   nil,
 				selectors: nil,
 				err:       fmt.Sprintf("name '%s' is not valid, must begin from ':'", "name2"),
 			},
 		},
+		{
+			in: []string{"file1", "http://url", "file2", "div"},
+			out: parseArgsResult{
+				sources:   []string{"file1", "http://url", "file2"},
+				selectors: map[string]string{"one": "div"},
+				err:       "",
+			},
+		},
+		{
+			in: []string{"file1", "file2", ":name1", "div1", ":name2", "div2"},
+			out: parseArgsResult{
+				sources:   []string{"file1", "file2"},
+				selectors: map[string]string{"name1": "div1", "name2": "div2"},
+				err:       "",
+			},
+		},
+		{
+			in: []string{"file", ":name1", "div1", ":name2"},
+			out: parseArgsResult{
+				sources:   nil,
+				selectors: nil,
+				err:       "selector for name ':name2' is empty",
+			},
+		},
 	}
 
 	for i, item := range testData {
-		var selectors map[string]string
-		url, selectors, err := parseArgs(item.in)
+		sources, selectors, err := parseArgs(item.in)
 		errMsg := ""
 		if err != nil {
 			errMsg = err.Error()
 		}
-		out := parseArgsResult{url, selectors, errMsg}
+		out := parseArgsResult{sources, selectors, errMsg}
 
 		if !reflect.DeepEqual(item.out, out) {
 			t.Errorf("\n%d. expected: %#v\n       real: %#v", i, item.out, out)
 		}
 	}
 }
+
+func Test_parseSourcesArgs(t *testing.T) {
+	testData := []struct {
+		in      []string
+		sources []string
+	}{
+		{in: []string{}, sources: []string{"-"}},
+		{in: []string{"file.html"}, sources: []string{"file.html"}},
+		{in: []string{"file.html", "http://url"}, sources: []string{"file.html", "http://url"}},
+	}
+
+	for i, item := range testData {
+		sources := parseSourcesArgs(item.in)
+		if !reflect.DeepEqual(sources, item.sources) {
+			t.Errorf("%d. parseSourcesArgs(%#v): got: %#v", i, item.in, sources)
+		}
+	}
+}
+
+func Test_readInputList(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "list.txt")
+	if err := os.WriteFile(fileName, []byte("# sources\nhttp://url\n\n  file.html  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sources, err := readInputList(fileName)
+	if err != nil || !reflect.DeepEqual(sources, []string{"http://url", "file.html"}) {
+		t.Errorf("readInputList(): got: %#v, %v", sources, err)
+	}
+
+	if _, err := readInputList(filepath.Join(t.TempDir(), "not-exists.txt")); err == nil {
+		t.Errorf("readInputList() for not existing file: error expected")
+	}
+}
+
+func Test_expandGlobs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.html", "b.html", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := expandGlobs([]string{"http://url/?a=*", filepath.Join(dir, "*.html"), filepath.Join(dir, "*.none"), "-"})
+	expected := []string{"http://url/?a=*", filepath.Join(dir, "a.html"), filepath.Join(dir, "b.html"), filepath.Join(dir, "*.none"), "-"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expandGlobs():\n expected: %#v\n      got: %#v", expected, got)
+	}
+}
+
+func Test_isMIMEFile(t *testing.T) {
+	testData := map[string]bool{
+		"page.mhtml":   true,
+		"page.MHT":     true,
+		"mail.eml.gz":  true,
+		"page.html":    false,
+		"mhtml":        false,
+		"page.html.gz": false,
+	}
+
+	for fileName, expected := range testData {
+		if got := isMIMEFile(fileName); got != expected {
+			t.Errorf("isMIMEFile(%q): expected: %v, got: %v", fileName, expected, got)
+		}
+	}
+}
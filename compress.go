@@ -0,0 +1,98 @@
+package html2data
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ErrUnsupportedCompression - error for compressed input which can't be decompressed (xz, brotli)
+var ErrUnsupportedCompression = errors.New("unsupported compression")
+
+// compressionMagic - magic bytes of compressed formats
+var compressionMagic = []struct {
+	name  string
+	magic []byte
+}{
+	{name: "gzip", magic: []byte{0x1f, 0x8b}},
+	{name: "bzip2", magic: []byte("BZh")},
+	{name: "zstd", magic: []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{name: "xz", magic: []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+}
+
+// decompress - detect compression of input by magic bytes and get decompressed reader,
+// gzip, bzip2 and zstd are supported, xz is detected but returns ErrUnsupportedCompression
+func decompress(reader io.Reader) (io.Reader, error) {
+	bufReader := bufio.NewReader(reader)
+	for _, format := range compressionMagic {
+		magic, _ := bufReader.Peek(len(format.magic))
+		if !bytes.Equal(magic, format.magic) {
+			continue
+		}
+
+		switch format.name {
+		case "gzip":
+			return gzip.NewReader(bufReader)
+		case "bzip2":
+			// "BZh" and block size from 1 to 9
+			if magic, _ := bufReader.Peek(4); len(magic) < 4 || magic[3] < '1' || magic[3] > '9' {
+				continue
+			}
+			return bzip2.NewReader(bufReader), nil
+		case "zstd":
+			// synchronous decoder without goroutines, so it needs no Close
+			return zstd.NewReader(bufReader, zstd.WithDecoderConcurrency(1))
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedCompression, format.name)
+		}
+	}
+
+	return bufReader, nil
+}
+
+// decodeContentEncoding - decode body by Content-Encoding header (gzip, deflate, zstd),
+// for responses which were not decompressed by transport (e.g. cached or recorded)
+func decodeContentEncoding(header http.Header, body []byte) ([]byte, error) {
+	encodings := strings.Split(header.Get("Content-Encoding"), ",")
+	// encodings are listed in the order in which they were applied
+	for i := len(encodings) - 1; i >= 0; i-- {
+		var (
+			reader io.Reader
+			err    error
+		)
+		switch encoding := strings.ToLower(strings.TrimSpace(encodings[i])); encoding {
+		case "", "identity":
+			continue
+		case "gzip", "x-gzip":
+			reader, err = gzip.NewReader(bytes.NewReader(body))
+		case "deflate":
+			// "deflate" is zlib format by RFC, but some servers send raw deflate
+			if reader, err = zlib.NewReader(bytes.NewReader(body)); err != nil {
+				reader, err = flate.NewReader(bytes.NewReader(body)), nil
+			}
+		case "zstd":
+			reader, err = zstd.NewReader(bytes.NewReader(body), zstd.WithDecoderConcurrency(1))
+		default:
+			return nil, fmt.Errorf("%w: Content-Encoding %s", ErrUnsupportedCompression, encoding)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if body, err = io.ReadAll(reader); err != nil {
+			return nil, err
+		}
+	}
+
+	return body, nil
+}
@@ -0,0 +1,144 @@
+package html2data
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// bzip2 of "<title>bzip2</title>"
+var bzip2Title = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x11, 0x0a, 0x80, 0x33, 0x00, 0x00,
+	0x01, 0x99, 0x80, 0x00, 0x00, 0x90, 0x05, 0x12, 0x24, 0x44, 0x10, 0x20, 0x00, 0x21, 0x2a, 0x64,
+	0xc0, 0xd0, 0x80, 0x69, 0xa6, 0x87, 0xcf, 0xc5, 0x0d, 0x82, 0x40, 0xb7, 0x5c, 0x5c, 0x2e, 0xe4,
+	0x8a, 0x70, 0xa1, 0x20, 0x22, 0x15, 0x00, 0x66,
+}
+
+func gzipBytes(t *testing.T, content string) []byte {
+	buf := bytes.Buffer{}
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zstdBytes(t *testing.T, content string) []byte {
+	buf := bytes.Buffer{}
+	writer, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := writer.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func Test_FromReaderCompressed(t *testing.T) {
+	testData := []struct {
+		name    string
+		content []byte
+		title   string
+		err     error
+	}{
+		{name: "plain", content: []byte("<title>plain</title>"), title: "plain"},
+		{name: "gzip", content: gzipBytes(t, "<title>gzip</title>"), title: "gzip"},
+		{name: "bzip2", content: bzip2Title, title: "bzip2"},
+		{name: "text like bzip2", content: []byte("BZh <title>text</title>"), title: "text"},
+		{name: "zstd", content: zstdBytes(t, "<title>zstd</title>"), title: "zstd"},
+		{name: "xz", content: []byte{0xfd, '7', 'z', 'X', 'Z', 0x00, 0x00}, err: ErrUnsupportedCompression},
+	}
+
+	for _, item := range testData {
+		doc := FromReader(bytes.NewReader(item.content))
+		if item.err != nil {
+			if !errors.Is(doc.Err, item.err) {
+				t.Errorf("%s: expected error %v, got: %v", item.name, item.err, doc.Err)
+			}
+			continue
+		}
+
+		title, err := doc.GetDataSingle("title")
+		if err != nil || title != item.title {
+			t.Errorf("%s: expected: %q, got: %q, %v", item.name, item.title, title, err)
+		}
+	}
+
+	fileName := filepath.Join(t.TempDir(), "page.html.gz")
+	if err := os.WriteFile(fileName, gzipBytes(t, "<title>file</title>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if title, err := FromFile(fileName).GetDataSingle("title"); err != nil || title != "file" {
+		t.Errorf("FromFile() gzip: got: %q, %v", title, err)
+	}
+
+	fileName = filepath.Join(t.TempDir(), "page.html.zst")
+	if err := os.WriteFile(fileName, zstdBytes(t, "<title>zstd file</title>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if title, err := FromFile(fileName).GetDataSingle("title"); err != nil || title != "zstd file" {
+		t.Errorf("FromFile() zstd: got: %q, %v", title, err)
+	}
+}
+
+func Test_FromURLContentEncoding(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		switch r.URL.Path {
+		case "/deflate":
+			w.Header().Set("Content-Encoding", "deflate")
+			writer := zlib.NewWriter(w)
+			_, _ = writer.Write([]byte("<title>deflate</title>"))
+			_ = writer.Close()
+		case "/gzip-file":
+			_, _ = w.Write(gzipBytes(t, "<title>gzip file</title>"))
+		case "/br":
+			w.Header().Set("Content-Encoding", "br")
+			_, _ = w.Write([]byte("..."))
+		}
+	}))
+	defer ts.Close()
+
+	for path, expected := range map[string]string{"/deflate": "deflate", "/gzip-file": "gzip file"} {
+		title, err := FromURL(ts.URL + path).GetDataSingle("title")
+		if err != nil || title != expected {
+			t.Errorf("FromURL(%s): expected: %q, got: %q, %v", path, expected, title, err)
+		}
+	}
+
+	if doc := FromURL(ts.URL + "/br"); !errors.Is(doc.Err, ErrUnsupportedCompression) {
+		t.Errorf("FromURL(/br): expected error, got: %v", doc.Err)
+	}
+}
+
+func Test_decodeContentEncoding(t *testing.T) {
+	header := http.Header{"Content-Encoding": {"gzip, gzip"}}
+	body, err := decodeContentEncoding(header, gzipBytes(t, string(gzipBytes(t, "text"))))
+	if err != nil || string(body) != "text" {
+		t.Errorf("decodeContentEncoding(): got: %q, %v", body, err)
+	}
+
+	body, err = decodeContentEncoding(http.Header{"Content-Encoding": {"zstd"}}, zstdBytes(t, "zstd text"))
+	if err != nil || string(body) != "zstd text" {
+		t.Errorf("decodeContentEncoding() zstd: got: %q, %v", body, err)
+	}
+
+	if _, err := decodeContentEncoding(http.Header{"Content-Encoding": {"gzip"}}, []byte("text")); err == nil {
+		t.Errorf("decodeContentEncoding() with invalid gzip: error expected")
+	}
+}
@@ -0,0 +1,235 @@
+package html2data
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+)
+
+// CrawlerCfg - config for Crawl()
+type CrawlerCfg struct {
+	URLCfg             URLCfg            // config for load pages
+	Concurrency        int               // max count of parallel requests, 4 by default
+	PerHostConcurrency int               // max count of parallel requests to one host, 0 - without limit
+	MaxDepth           int               // max depth of following links from seed URLs, 0 - don't follow links
+	MaxPages           int               // max count of loaded pages, 0 - without limit
+	SameHost           bool              // follow links only to hosts of seed URLs
+	Include            *regexp.Regexp    // follow only links matched by regexp
+	Exclude            *regexp.Regexp    // don't follow links matched by regexp
+	Selectors          map[string]string // selectors for extract data from each page (CrawlResult.Data)
+	Cfg                Cfg               // config for extract data by Selectors
+}
+
+// CrawlResult - result of loading one page by Crawl()
+type CrawlResult struct {
+	URL   string              // requested URL
+	Depth int                 // depth from seed URL
+	Doc   Doc                 // loaded document
+	Data  map[string][]string // data extracted by CrawlerCfg.Selectors
+	Err   error               // error of loading or extracting page
+}
+
+type crawlTask struct {
+	url   *url.URL
+	depth int
+}
+
+type crawlDone struct {
+	task   crawlTask
+	result CrawlResult
+	links  []*url.URL
+}
+
+// crawler - state of one Crawl()
+type crawler struct {
+	config     CrawlerCfg
+	jar        http.CookieJar
+	seedHosts  map[string]bool
+	visited    map[string]bool
+	queue      []crawlTask
+	hostActive map[string]int
+	active     int
+	queued     int
+	done       chan crawlDone
+}
+
+// Crawl - load pages from seed URLs with bounded concurrency and follow links by rules,
+// results are sent to returned channel, which is closed after all pages are loaded or ctx is done
+//
+//	results := html2data.Crawl(ctx, []string{"http://example.com/"}, html2data.CrawlerCfg{MaxDepth: 2, SameHost: true})
+//	for result := range results {
+//		if result.Err != nil {
+//			...
+//		}
+//		title, _ := result.Doc.GetDataSingle("title")
+//	}
+func Crawl(ctx context.Context, seeds []string, config CrawlerCfg) <-chan CrawlResult {
+	if config.Concurrency <= 0 {
+		config.Concurrency = 4
+	}
+
+	results := make(chan CrawlResult)
+	crawler := &crawler{
+		config:     config,
+		seedHosts:  map[string]bool{},
+		visited:    map[string]bool{},
+		hostActive: map[string]int{},
+		done:       make(chan crawlDone, config.Concurrency),
+	}
+
+	go func() {
+		defer close(results)
+
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			results <- CrawlResult{Err: err}
+			return
+		}
+		crawler.jar = jar
+
+		for _, seed := range seeds {
+			seedURL, err := url.Parse(seed)
+			if err != nil {
+				select {
+				case results <- CrawlResult{URL: seed, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+			crawler.seedHosts[seedURL.Hostname()] = true
+			crawler.enqueue(crawlTask{url: seedURL})
+		}
+
+		crawler.run(ctx, results)
+	}()
+
+	return results
+}
+
+// run - main loop of crawler, starts tasks and collects results
+func (crawler *crawler) run(ctx context.Context, results chan<- CrawlResult) {
+	for {
+		crawler.startTasks(ctx)
+		if crawler.active == 0 {
+			return
+		}
+
+		select {
+		case done := <-crawler.done:
+			crawler.active--
+			crawler.hostActive[done.task.url.Host]--
+
+			for _, link := range done.links {
+				if crawler.isFollowed(link) {
+					crawler.enqueue(crawlTask{url: link, depth: done.task.depth + 1})
+				}
+			}
+
+			select {
+			case results <- done.result:
+			case <-ctx.Done():
+				crawler.wait()
+				return
+			}
+		case <-ctx.Done():
+			crawler.wait()
+			return
+		}
+	}
+}
+
+// startTasks - start tasks from queue within concurrency limits
+func (crawler *crawler) startTasks(ctx context.Context) {
+	for i := 0; i < len(crawler.queue) && crawler.active < crawler.config.Concurrency; {
+		task := crawler.queue[i]
+		if crawler.config.PerHostConcurrency > 0 && crawler.hostActive[task.url.Host] >= crawler.config.PerHostConcurrency {
+			i++
+			continue
+		}
+
+		crawler.queue = append(crawler.queue[:i], crawler.queue[i+1:]...)
+		crawler.active++
+		crawler.hostActive[task.url.Host]++
+		go func() {
+			crawler.done <- crawler.load(ctx, task)
+		}()
+	}
+}
+
+// wait - wait for active tasks
+func (crawler *crawler) wait() {
+	for ; crawler.active > 0; crawler.active-- {
+		<-crawler.done
+	}
+}
+
+// enqueue - add task to queue if URL is not visited and pages limit is not exceeded
+func (crawler *crawler) enqueue(task crawlTask) {
+	task.url.Fragment = ""
+	key := task.url.String()
+	if crawler.visited[key] || (crawler.config.MaxPages > 0 && crawler.queued >= crawler.config.MaxPages) {
+		return
+	}
+
+	crawler.visited[key] = true
+	crawler.queued++
+	crawler.queue = append(crawler.queue, task)
+}
+
+// isFollowed - check link by rules of config
+func (crawler *crawler) isFollowed(link *url.URL) bool {
+	linkURL := link.String()
+	switch {
+	case link.Scheme != "http" && link.Scheme != "https":
+		return false
+	case crawler.config.SameHost && !crawler.seedHosts[link.Hostname()]:
+		return false
+	case crawler.config.Include != nil && !crawler.config.Include.MatchString(linkURL):
+		return false
+	case crawler.config.Exclude != nil && crawler.config.Exclude.MatchString(linkURL):
+		return false
+	}
+
+	return true
+}
+
+// load - load page and extract data and links
+func (crawler *crawler) load(ctx context.Context, task crawlTask) (done crawlDone) {
+	done.task = task
+	done.result = CrawlResult{URL: task.url.String(), Depth: task.depth}
+
+	request, err := http.NewRequestWithContext(ctx, "GET", task.url.String(), nil)
+	if err != nil {
+		done.result.Err = err
+		return done
+	}
+
+	doc := fromRequest(request, crawler.config.URLCfg, crawler.jar)
+	done.result.Doc = doc
+	if doc.Err != nil {
+		done.result.Err = doc.Err
+		return done
+	}
+
+	if crawler.config.Selectors != nil {
+		done.result.Data, done.result.Err = doc.GetData(crawler.config.Selectors, crawler.config.Cfg)
+	}
+
+	if task.depth < crawler.config.MaxDepth {
+		links, err := doc.Links()
+		if err != nil {
+			done.result.Err = err
+			return done
+		}
+		for _, link := range links {
+			if linkURL, err := url.Parse(link.URL); err == nil && linkURL.IsAbs() {
+				done.links = append(done.links, linkURL)
+			}
+		}
+	}
+
+	return done
+}
@@ -0,0 +1,91 @@
+package html2data
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_Crawl(t *testing.T) {
+	var active, maxActive int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			max := atomic.LoadInt32(&maxActive)
+			if current <= max || atomic.CompareAndSwapInt32(&maxActive, max, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		switch r.URL.Path {
+		case "/":
+			_, _ = fmt.Fprint(w, `<title>index</title><a href="/a">a</a><a href="/b#x">b</a><a href="/skip">skip</a><a href="http://other.invalid/">other</a>`)
+		case "/a":
+			_, _ = fmt.Fprint(w, `<title>a</title><a href="/">index</a><a href="/a/deep">deep</a>`)
+		case "/b":
+			_, _ = fmt.Fprint(w, `<title>b</title><a href="/b/deep">deep</a>`)
+		default:
+			_, _ = fmt.Fprintf(w, `<title>%s</title>`, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	crawl := func(config CrawlerCfg) (titles []string, errors int) {
+		for result := range Crawl(context.Background(), []string{ts.URL + "/"}, config) {
+			if result.Err != nil {
+				errors++
+				continue
+			}
+			titles = append(titles, fmt.Sprintf("%d:%s", result.Depth, result.Data["title"][0]))
+		}
+		sort.Strings(titles)
+		return titles, errors
+	}
+
+	selectors := map[string]string{"title": "title"}
+	titles, errors := crawl(CrawlerCfg{Selectors: selectors})
+	if expected := []string{"0:index"}; !reflect.DeepEqual(expected, titles) || errors != 0 {
+		t.Errorf("Crawl() without depth\nexpected: %#v\nreal    : %#v, errors: %d", expected, titles, errors)
+	}
+
+	titles, errors = crawl(CrawlerCfg{Selectors: selectors, MaxDepth: 2, SameHost: true, Exclude: regexp.MustCompile(`/skip`), PerHostConcurrency: 2})
+	if expected := []string{"0:index", "1:a", "1:b", "2:/a/deep", "2:/b/deep"}; !reflect.DeepEqual(expected, titles) || errors != 0 {
+		t.Errorf("Crawl() with depth\nexpected: %#v\nreal    : %#v, errors: %d", expected, titles, errors)
+	}
+	if atomic.LoadInt32(&maxActive) > 2 {
+		t.Errorf("Crawl() per host concurrency exceeded: %d", maxActive)
+	}
+
+	titles, _ = crawl(CrawlerCfg{Selectors: selectors, MaxDepth: 1, Include: regexp.MustCompile(`/a$`)})
+	if expected := []string{"0:index", "1:a"}; !reflect.DeepEqual(expected, titles) {
+		t.Errorf("Crawl() with include\nexpected: %#v\nreal    : %#v", expected, titles)
+	}
+
+	titles, errors = crawl(CrawlerCfg{Selectors: selectors, MaxDepth: 1, MaxPages: 3})
+	if len(titles)+errors != 3 {
+		t.Errorf("Crawl() with MaxPages got: %#v, errors: %d", titles, errors)
+	}
+
+	// error for URL of other host
+	_, errors = crawl(CrawlerCfg{Selectors: selectors, MaxDepth: 1, Include: regexp.MustCompile(`other`), URLCfg: URLCfg{TimeOut: 1}})
+	if errors != 1 {
+		t.Errorf("Crawl() with error got errors: %d", errors)
+	}
+
+	// cancel
+	ctx, cancel := context.WithCancel(context.Background())
+	results := Crawl(ctx, []string{ts.URL + "/"}, CrawlerCfg{MaxDepth: 2})
+	<-results
+	cancel()
+	for range results {
+	}
+}
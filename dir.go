@@ -0,0 +1,113 @@
+package html2data
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrStopDir - return it from extract function for stop FromDir() or WalkDir() without error
+var ErrStopDir = errors.New("stop dir")
+
+// defaultDirInclude - HTML files and compressed HTML files, which are decompressed by FromFile()
+var defaultDirInclude = []string{"*.html", "*.htm", "*.html.gz", "*.htm.gz", "*.html.bz2", "*.htm.bz2", "*.html.zst", "*.htm.zst"}
+
+// DirCfg - config for FromDir() and WalkDir()
+type DirCfg struct {
+	Include []string // glob patterns of files, "*.html" and "*.htm" by default, compressed too (see defaultDirInclude)
+	Exclude []string // glob patterns of skipped files and directories
+}
+
+// FromDir - walk directory tree in lexical order and call extract for each file matched by patterns,
+// file path is relative to dir and uses "/" as separator, errors of loading file are passed in doc.Err.
+// Patterns without "/" are matched against file name, others against relative path.
+//
+//	err := html2data.FromDir("mirror", func(path string, doc html2data.Doc) error {
+//		if doc.Err != nil {
+//			return doc.Err
+//		}
+//		title, err := doc.GetDataSingle("title")
+//		...
+//	}, html2data.DirCfg{Exclude: []string{"tmp", "*.draft.html"}})
+func FromDir(dir string, extract func(path string, doc Doc) error, configs ...DirCfg) error {
+	if len(configs) > 1 {
+		panic("FromDir(): only one config argument allowed")
+	}
+
+	return WalkDir(dir, func(relPath string) error {
+		return extract(relPath, FromFile(filepath.Join(dir, filepath.FromSlash(relPath))))
+	}, configs...)
+}
+
+// WalkDir - like FromDir() but without parsing, call walk with relative path of each file matched by patterns,
+// for parse files in other goroutines
+func WalkDir(dir string, walk func(path string) error, configs ...DirCfg) error {
+	var config DirCfg
+	switch {
+	case len(configs) == 1:
+		config = configs[0]
+	case len(configs) > 1:
+		panic("WalkDir(): only one config argument allowed")
+	}
+	if len(config.Include) == 0 {
+		config.Include = defaultDirInclude
+	}
+
+	for _, patterns := range [][]string{config.Include, config.Exclude} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("pattern %q: %s", pattern, err)
+			}
+		}
+	}
+
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if relPath == "." {
+			return nil
+		}
+
+		if matchGlobs(config.Exclude, relPath) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || !entry.Type().IsRegular() || !matchGlobs(config.Include, relPath) {
+			return nil
+		}
+
+		return walk(relPath)
+	})
+	if errors.Is(err, ErrStopDir) {
+		return nil
+	}
+
+	return err
+}
+
+// matchGlobs - check relative path by glob patterns
+func matchGlobs(patterns []string, relPath string) bool {
+	for _, pattern := range patterns {
+		name := relPath
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(relPath)
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
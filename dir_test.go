@@ -0,0 +1,111 @@
+package html2data
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_FromDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.html":            "<title>index</title>",
+		"about.htm":             "<title>about</title>",
+		"archive.html.gz":       string(gzipBytes(t, "<title>archive</title>")),
+		"archive.html.zst":      string(zstdBytes(t, "<title>archive zst</title>")),
+		"notes.txt":             "not html",
+		"blog/post1.html":       "<title>post1</title>",
+		"blog/post2.draft.html": "<title>draft</title>",
+		"tmp/cache.html":        "<title>cache</title>",
+	}
+	for name, content := range files {
+		fileName := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fileName, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testData := []struct {
+		config   DirCfg
+		expected map[string]string
+	}{
+		{
+			config: DirCfg{},
+			expected: map[string]string{
+				"about.htm":             "about",
+				"archive.html.gz":       "archive",
+				"archive.html.zst":      "archive zst",
+				"blog/post1.html":       "post1",
+				"blog/post2.draft.html": "draft",
+				"index.html":            "index",
+				"tmp/cache.html":        "cache",
+			},
+		},
+		{
+			config: DirCfg{Exclude: []string{"tmp", "*.draft.html"}},
+			expected: map[string]string{
+				"about.htm":        "about",
+				"archive.html.gz":  "archive",
+				"archive.html.zst": "archive zst",
+				"blog/post1.html":  "post1",
+				"index.html":       "index",
+			},
+		},
+		{
+			config:   DirCfg{Include: []string{"blog/*.html"}, Exclude: []string{"*.draft.html"}},
+			expected: map[string]string{"blog/post1.html": "post1"},
+		},
+	}
+
+	for i, item := range testData {
+		titles := map[string]string{}
+		err := FromDir(dir, func(path string, doc Doc) error {
+			if doc.Err != nil {
+				return doc.Err
+			}
+			title, err := doc.GetDataSingle("title")
+			titles[path] = title
+			return err
+		}, item.config)
+		if err != nil {
+			t.Errorf("%d. FromDir() got error: %s", i, err)
+		}
+		if !reflect.DeepEqual(item.expected, titles) {
+			t.Errorf("%d. FromDir()\nexpected: %#v\nreal    : %#v", i, item.expected, titles)
+		}
+	}
+
+	count := 0
+	err := FromDir(dir, func(path string, doc Doc) error {
+		count++
+		return ErrStopDir
+	})
+	if err != nil || count != 1 {
+		t.Errorf("FromDir() with ErrStopDir: got: %d, %v", count, err)
+	}
+
+	if err := FromDir(dir, func(string, Doc) error { return nil }, DirCfg{Include: []string{"["}}); err == nil {
+		t.Errorf("FromDir() with invalid pattern: error expected")
+	}
+
+	if err := FromDir(filepath.Join(dir, "not-exists"), func(string, Doc) error { return nil }); err == nil {
+		t.Errorf("FromDir() with not existing dir: error expected")
+	}
+
+	paths := []string{}
+	err = WalkDir(dir, func(path string) error {
+		paths = append(paths, path)
+		return nil
+	}, DirCfg{Exclude: []string{"tmp", "*.draft.html"}})
+	if expected := []string{"about.htm", "archive.html.gz", "archive.html.zst", "blog/post1.html", "index.html"}; err != nil || !reflect.DeepEqual(expected, paths) {
+		t.Errorf("WalkDir()\nexpected: %#v\nreal    : %#v, %v", expected, paths, err)
+	}
+
+	assertPanic(t, func() {
+		_ = FromDir(dir, func(string, Doc) error { return nil }, DirCfg{}, DirCfg{})
+	}, "FromDir() with 2 config arguments")
+}
@@ -0,0 +1,121 @@
+package html2data
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// Feed - RSS or Atom feed, result of ParseFeed()
+type Feed struct {
+	Title string     `json:"title"`
+	Link  string     `json:"link"`
+	Items []FeedItem `json:"items"`
+}
+
+// FeedItem - item of RSS or entry of Atom feed
+type FeedItem struct {
+	Title   string    `json:"title"`
+	Link    string    `json:"link"`
+	Date    time.Time `json:"date"`    // zero if not found
+	Summary string    `json:"summary"` // text without HTML tags
+}
+
+// feedItemSelectors - selectors for fields of RSS 2.0, RSS 1.0 (RDF) items and Atom entries, in order of priority
+var feedItemSelectors = map[string][]string{
+	"title":   {"title"},
+	"link":    {"link", `link[rel="alternate"]:attr(href)`, "link:attr(href)", "guid"},
+	"date":    {"pubDate", "dc|date", "published", "updated"},
+	"summary": {"description", "summary", "content|encoded", "content"},
+}
+
+// ParseFeed - parse RSS (2.0, 1.0) or Atom feed, items are normalized to title, link, date and summary
+//
+//	feed, err := html2data.ParseFeed(reader)
+//	for _, item := range feed.Items {
+//		fmt.Println(item.Date, item.Title, item.Link)
+//	}
+func ParseFeed(reader io.Reader) (feed Feed, err error) {
+	doc := FromReaderXML(reader)
+	if doc.Err != nil {
+		return feed, doc.Err
+	}
+
+	if doc.doc.Find(xmlSelector("rss, rdf|RDF, feed")).Length() == 0 {
+		return feed, errors.New("RSS or Atom feed is not found")
+	}
+
+	channel, err := doc.GetDataFirst(map[string]string{
+		"title":     "channel > title, feed > title",
+		"link":      "channel > link",
+		"alternate": `feed > link[rel="alternate"]:attr(href)`,
+		"href":      "feed > link:attr(href)",
+	})
+	if err != nil {
+		return feed, err
+	}
+	feed.Title = channel["title"]
+	feed.Link = firstNonEmptyString(channel["link"], channel["alternate"], channel["href"])
+
+	selectors := map[string]string{}
+	for field, fieldSelectors := range feedItemSelectors {
+		for i, selector := range fieldSelectors {
+			selectors[field+strings.Repeat("-", i)] = selector
+		}
+	}
+
+	items, err := doc.GetDataNestedFirst("item, entry", selectors)
+	if err != nil {
+		return feed, err
+	}
+
+	feed.Items = []FeedItem{}
+	for _, item := range items {
+		values := map[string]string{}
+		for field, fieldSelectors := range feedItemSelectors {
+			for i := range fieldSelectors {
+				if values[field] = item[field+strings.Repeat("-", i)]; values[field] != "" {
+					break
+				}
+			}
+		}
+
+		feedItem := FeedItem{
+			Title:   values["title"],
+			Link:    values["link"],
+			Summary: htmlToText(values["summary"]),
+		}
+		if date, ok := parseDate(values["date"], "", nil); ok {
+			feedItem.Date = date
+		}
+		feed.Items = append(feed.Items, feedItem)
+	}
+
+	return feed, nil
+}
+
+// firstNonEmptyString - get first not empty string
+func firstNonEmptyString(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// htmlToText - get text of HTML fragment with normalized spaces
+func htmlToText(fragment string) string {
+	if !strings.Contains(fragment, "<") && !strings.Contains(fragment, "&") {
+		return normalizeSpaces(fragment)
+	}
+
+	doc := FromReader(strings.NewReader(fragment))
+	if doc.Err != nil {
+		return normalizeSpaces(fragment)
+	}
+
+	return normalizeSpaces(doc.doc.Find("body").Text())
+}
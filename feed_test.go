@@ -0,0 +1,70 @@
+package html2data
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_ParseFeed(t *testing.T) {
+	atom := `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+	<title>Atom feed</title>
+	<link href="https://example.com/feed.xml" rel="self"/>
+	<link href="https://example.com/" rel="alternate"/>
+	<entry>
+		<title>Entry</title>
+		<link href="https://example.com/entry" rel="alternate"/>
+		<updated>2024-01-02T03:04:05Z</updated>
+		<summary type="html">&lt;p&gt;Summary  of &lt;b&gt;entry&lt;/b&gt;&lt;/p&gt;</summary>
+	</entry>
+</feed>`
+
+	testData := []struct {
+		name    string
+		content string
+		feed    Feed
+	}{
+		{
+			name:    "rss",
+			content: testXML,
+			feed: Feed{
+				Title: "Feed",
+				Items: []FeedItem{
+					{Title: "First", Link: "https://example.com/1", Date: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Summary: "Text & bold"},
+					{Title: "Second", Link: "https://example.com/2", Date: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+		},
+		{
+			name:    "atom",
+			content: atom,
+			feed: Feed{
+				Title: "Atom feed",
+				Link:  "https://example.com/",
+				Items: []FeedItem{
+					{Title: "Entry", Link: "https://example.com/entry", Date: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Summary: "Summary of entry"},
+				},
+			},
+		},
+	}
+
+	for _, item := range testData {
+		feed, err := ParseFeed(strings.NewReader(item.content))
+		if err != nil {
+			t.Errorf("%s: ParseFeed() got error: %s", item.name, err)
+			continue
+		}
+		for i := range feed.Items {
+			feed.Items[i].Date = feed.Items[i].Date.UTC()
+		}
+		if !reflect.DeepEqual(item.feed, feed) {
+			t.Errorf("%s: ParseFeed()\nexpected: %#v\nreal    : %#v", item.name, item.feed, feed)
+		}
+	}
+
+	if _, err := ParseFeed(strings.NewReader("<html><body>page</body></html>")); err == nil {
+		t.Errorf("ParseFeed() for not feed: error expected")
+	}
+}
@@ -0,0 +1,279 @@
+package html2data
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Form - html form of document, result of Forms()
+type Form struct {
+	Name    string      `json:"name,omitempty"`
+	ID      string      `json:"id,omitempty"`
+	Action  string      `json:"action"` // absolute URL if document URL is known
+	Method  string      `json:"method"` // GET or POST
+	Enctype string      `json:"enctype"`
+	Fields  []FormField `json:"fields"`
+
+	jar http.CookieJar // cookies of session which loaded document
+}
+
+// FormField - input, select, textarea or button of form
+type FormField struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"` // type of input, "select", "textarea" or "button"
+	Value    string   `json:"value"`
+	Checked  bool     `json:"checked,omitempty"`  // for checkbox and radio
+	Disabled bool     `json:"disabled,omitempty"` // disabled fields are not submitted
+	Options  []string `json:"options,omitempty"`  // values of select options
+	Selected []string `json:"selected,omitempty"` // selected values of select (first option by default)
+	Multiple bool     `json:"multiple,omitempty"`
+
+	submit bool // submit button: input type=submit/image or button with type=submit
+}
+
+// Forms - get all forms of document with fields and default values,
+// fields outside of form linked by form="id" attribute are included in document order
+//
+//	forms, err := html2data.FromURL("http://example.com/search").Forms()
+func (doc Doc) Forms() (result []Form, err error) {
+	if doc.Err != nil {
+		return result, fmt.Errorf("parse document error: %s", doc.Err)
+	}
+
+	base, err := doc.baseURL()
+	if err != nil {
+		return result, err
+	}
+
+	result = []Form{}
+	fields := doc.doc.Find("input, select, textarea, button")
+	doc.doc.Find("form").Each(func(_ int, formSelection *goquery.Selection) {
+		form := Form{
+			Name:    formSelection.AttrOr("name", ""),
+			ID:      formSelection.AttrOr("id", ""),
+			Method:  strings.ToUpper(strings.TrimSpace(formSelection.AttrOr("method", "GET"))),
+			Enctype: strings.ToLower(strings.TrimSpace(formSelection.AttrOr("enctype", "application/x-www-form-urlencoded"))),
+			Fields:  []FormField{},
+			jar:     doc.jar,
+		}
+		if form.Method != "POST" {
+			form.Method = "GET"
+		}
+
+		// empty action is the URL of document
+		if actionURL, ok := resolveURL(base, formSelection.AttrOr("action", "")); ok {
+			form.Action = actionURL.String()
+		} else if base != nil {
+			form.Action = base.String()
+		} else {
+			form.Action = doc.URL
+		}
+
+		fields.Each(func(_ int, field *goquery.Selection) {
+			if isFormField(field, formSelection, form.ID) {
+				form.Fields = append(form.Fields, parseFormField(field))
+			}
+		})
+
+		result = append(result, form)
+	})
+
+	return result, nil
+}
+
+// isFormField - check that form is the owner of field: form attribute of field has priority over parent form
+func isFormField(field *goquery.Selection, form *goquery.Selection, formID string) bool {
+	if fieldFormID, ok := field.Attr("form"); ok {
+		return fieldFormID != "" && fieldFormID == formID
+	}
+
+	return field.Closest("form").IsSelection(form)
+}
+
+// parseFormField - get field of form with default value
+func parseFormField(field *goquery.Selection) FormField {
+	_, disabled := field.Attr("disabled")
+	_, multiple := field.Attr("multiple")
+	result := FormField{
+		Name:     field.AttrOr("name", ""),
+		Disabled: disabled,
+		Multiple: multiple,
+	}
+
+	switch goquery.NodeName(field) {
+	case "select":
+		result.Type = "select"
+		field.Find("option").Each(func(_ int, option *goquery.Selection) {
+			value, ok := option.Attr("value")
+			if !ok {
+				value = normalizeSpaces(option.Text())
+			}
+			result.Options = append(result.Options, value)
+			if _, selected := option.Attr("selected"); selected {
+				result.Selected = append(result.Selected, value)
+			}
+		})
+		if len(result.Selected) == 0 && len(result.Options) > 0 && !result.Multiple {
+			result.Selected = []string{result.Options[0]}
+		}
+		if len(result.Selected) > 0 {
+			result.Value = result.Selected[0]
+		}
+	case "textarea":
+		result.Type = "textarea"
+		result.Value = field.Text()
+	case "button":
+		result.Type = "button"
+		result.Value = field.AttrOr("value", "")
+		buttonType := strings.ToLower(strings.TrimSpace(field.AttrOr("type", "submit")))
+		result.submit = buttonType != "button" && buttonType != "reset"
+	default:
+		result.Type = strings.ToLower(field.AttrOr("type", "text"))
+		result.Value = field.AttrOr("value", "")
+		result.submit = result.Type == "submit" || result.Type == "image"
+		if result.Type == "checkbox" || result.Type == "radio" {
+			_, result.Checked = field.Attr("checked")
+			if result.Value == "" {
+				result.Value = "on"
+			}
+		}
+	}
+
+	return result
+}
+
+// Values - default values of form as browser submits it without user input:
+// checked checkboxes and radios, selected options, hidden and text fields, without buttons
+func (form Form) Values() url.Values {
+	result := url.Values{}
+	for _, field := range form.Fields {
+		if field.Name == "" || field.Disabled {
+			continue
+		}
+
+		switch field.Type {
+		case "submit", "button", "reset", "image", "file":
+			continue
+		case "checkbox", "radio":
+			if field.Checked {
+				result.Add(field.Name, field.Value)
+			}
+		case "select":
+			for _, value := range field.Selected {
+				result.Add(field.Name, value)
+			}
+		default:
+			result.Add(field.Name, field.Value)
+		}
+	}
+
+	return result
+}
+
+// Submit - submit form with default values replaced by values, returns resulting document,
+// cookies are shared with the request which loaded the form.
+// As on Enter in browser, name and value of the first submit button are submitted,
+// to press another button set its name in values. Attributes formaction, formmethod of buttons are not used.
+//
+//	doc := html2data.FromURL("http://example.com/search")
+//	forms, _ := doc.Forms()
+//	result := forms[0].Submit(url.Values{"q": {"query"}})
+func (form Form) Submit(values url.Values, config ...URLCfg) Doc {
+	if len(config) > 1 {
+		panic("Submit(): only one config argument allowed")
+	}
+
+	formValues := form.Values()
+	for name, value := range form.submitterValues(values) {
+		formValues[name] = value
+	}
+	for name, value := range values {
+		formValues[name] = value
+	}
+
+	request, err := form.newRequest(formValues)
+	if err != nil {
+		return Doc{Err: err}
+	}
+
+	return fromRequest(request, getURLConfig(config), form.jar)
+}
+
+// submitterValues - name and value of the first submit button (default button of form),
+// empty if other submit button is pressed (its name is set in values)
+func (form Form) submitterValues(values url.Values) url.Values {
+	result := url.Values{}
+	var defaultButton *FormField
+	for i, field := range form.Fields {
+		if !field.submit || field.Disabled {
+			continue
+		}
+		if field.Name != "" && (values.Has(field.Name) || values.Has(field.Name+".x")) {
+			return result
+		}
+		if defaultButton == nil {
+			defaultButton = &form.Fields[i]
+		}
+	}
+
+	switch {
+	case defaultButton == nil || defaultButton.Name == "":
+		// nothing to submit
+	case defaultButton.Type == "image":
+		// coordinates of click
+		result.Set(defaultButton.Name+".x", "0")
+		result.Set(defaultButton.Name+".y", "0")
+	default:
+		result.Set(defaultButton.Name, defaultButton.Value)
+	}
+
+	return result
+}
+
+// newRequest - create http request for submit form
+func (form Form) newRequest(values url.Values) (*http.Request, error) {
+	if form.Method != "POST" {
+		actionURL, err := url.Parse(form.Action)
+		if err != nil {
+			return nil, err
+		}
+		actionURL.RawQuery = values.Encode()
+		return http.NewRequest("GET", actionURL.String(), nil)
+	}
+
+	if form.Enctype != "multipart/form-data" {
+		request, err := http.NewRequest("POST", form.Action, strings.NewReader(values.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return request, nil
+	}
+
+	body := bytes.Buffer{}
+	writer := multipart.NewWriter(&body)
+	for name, list := range values {
+		for _, value := range list {
+			if err := writer.WriteField(name, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest("POST", form.Action, &body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return request, nil
+}
@@ -0,0 +1,93 @@
+package html2data
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func Test_Forms(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+			_, _ = fmt.Fprint(w, `<form action="/search" method="post" id="search">
+				<input type="hidden" name="csrf" value="token">
+				<input name="q" value="default">
+				<input type="checkbox" name="new" checked><input type="checkbox" name="old" value="1">
+				<input type="radio" name="sort" value="date"><input type="radio" name="sort" value="price" checked>
+				<select name="lang"><option value="en">English</option><option selected>de</option></select>
+				<textarea name="comment">text</textarea>
+				<input name="disabled" value="x" disabled>
+				<input type="submit" name="go" value="Search">
+				<button name="save" value="1">Save</button>
+				<button type="button" name="js">JS</button>
+				<input name="other" form="other">
+			</form>
+			<input name="outside" value="1" form="search">
+			<form id="other"><input name="q"></form>`)
+		case "/search":
+			cookie, err := r.Cookie("session")
+			if err != nil || cookie.Value != "abc" {
+				http.Error(w, "no session", http.StatusForbidden)
+				return
+			}
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			_, _ = fmt.Fprintf(w, "<div>%s</div>", r.PostForm.Encode())
+		}
+	}))
+	defer ts.Close()
+
+	forms, err := FromURL(ts.URL + "/").Forms()
+	if err != nil {
+		t.Fatalf("Forms() got error: %s", err)
+	}
+	if len(forms) != 2 {
+		t.Fatalf("Forms() expected 2 forms, got: %d", len(forms))
+	}
+
+	form := forms[0]
+	if form.ID != "search" || form.Action != ts.URL+"/search" || form.Method != "POST" || len(form.Fields) != 13 {
+		t.Errorf("Forms() got: %#v", form)
+	}
+	if forms[1].Action != ts.URL+"/" || forms[1].Method != "GET" || len(forms[1].Fields) != 2 {
+		t.Errorf("Forms() form without action got: %#v", forms[1])
+	}
+
+	expected := url.Values{
+		"csrf":    {"token"},
+		"q":       {"default"},
+		"new":     {"on"},
+		"sort":    {"price"},
+		"lang":    {"de"},
+		"comment": {"text"},
+		"outside": {"1"},
+	}
+	if !reflect.DeepEqual(expected, form.Values()) {
+		t.Errorf("Values()\nexpected: %#v\nreal    : %#v", expected, form.Values())
+	}
+
+	result, err := form.Submit(url.Values{"q": {"query"}}).GetDataSingle("div")
+	if err != nil || result != "comment=text&csrf=token&go=Search&lang=de&new=on&outside=1&q=query&sort=price" {
+		t.Errorf("Submit() got: %q, %v", result, err)
+	}
+
+	// pressed not the first submit button
+	result, err = form.Submit(url.Values{"save": {"1"}}).GetDataSingle("div")
+	if err != nil || result != "comment=text&csrf=token&lang=de&new=on&outside=1&q=default&save=1&sort=price" {
+		t.Errorf("Submit() with button got: %q, %v", result, err)
+	}
+
+	form.Enctype = "multipart/form-data"
+	if doc := form.Submit(nil); doc.Err != nil {
+		t.Errorf("Submit() multipart got error: %s", doc.Err)
+	}
+
+	assertPanic(t, func() { form.Submit(nil, URLCfg{}, URLCfg{}) }, "Submit() with 2 config arguments")
+}
@@ -1,9 +1,10 @@
 module github.com/msoap/html2data
 
-go 1.18
+go 1.22
 
 require (
 	github.com/PuerkitoBio/goquery v1.9.1
+	github.com/klauspost/compress v1.18.0
 	golang.org/x/net v0.22.0
 )
 
@@ -0,0 +1,92 @@
+package html2data
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// harFile - HTTP Archive (HAR) with fields used for import
+type harFile struct {
+	Log struct {
+		Entries []struct {
+			StartedDateTime time.Time `json:"startedDateTime"`
+			Request         struct {
+				URL string `json:"url"`
+			} `json:"request"`
+			Response struct {
+				Status  int `json:"status"`
+				Headers []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"headers"`
+				Content struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+					Encoding string `json:"encoding"`
+				} `json:"content"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+// ReadHAR - read HAR file exported from browser and call extract for each entry with HTML response,
+// documents are parsed from content as the browser saw it, entries without content are skipped
+//
+//	err = html2data.ReadHAR(file, func(record html2data.ArchiveRecord) error {
+//		title, err := record.Doc.GetDataSingle("title")
+//		...
+//	})
+func ReadHAR(reader io.Reader, extract func(ArchiveRecord) error) error {
+	har := harFile{}
+	if err := json.NewDecoder(reader).Decode(&har); err != nil {
+		return fmt.Errorf("parse HAR: %s", err)
+	}
+
+	for _, entry := range har.Log.Entries {
+		content := entry.Response.Content
+		header := http.Header{}
+		for _, item := range entry.Response.Headers {
+			header.Add(item.Name, item.Value)
+		}
+		if header.Get("Content-Type") == "" && content.MimeType != "" {
+			header.Set("Content-Type", content.MimeType)
+		}
+		if content.Text == "" || !isHTMLContentType(header.Get("Content-Type")) {
+			continue
+		}
+
+		record := ArchiveRecord{
+			URL:        entry.Request.URL,
+			Date:       entry.StartedDateTime,
+			StatusCode: entry.Response.Status,
+			Header:     header,
+		}
+
+		// content in HAR is already decoded from Content-Encoding, and text is UTF-8 if it is not base64
+		var err error
+		page := fetchedPage{URL: record.URL, Header: http.Header{"Content-Type": {"text/html; charset=utf-8"}}, Body: []byte(content.Text)}
+		if content.Encoding == "base64" {
+			page.Header.Set("Content-Type", header.Get("Content-Type"))
+			page.Body, err = base64.StdEncoding.DecodeString(content.Text)
+		}
+		if err != nil {
+			record.Doc = Doc{Err: fmt.Errorf("decode HAR content: %s", err), URL: record.URL}
+		} else {
+			record.Doc = page.doc()
+		}
+
+		if err := extract(record); err != nil {
+			if errors.Is(err, ErrStopArchive) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package html2data
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testHAR() string {
+	// "<title>Привет</title>" in windows-1251
+	cp1251 := base64.StdEncoding.EncodeToString([]byte("<title>\xcf\xf0\xe8\xe2\xe5\xf2</title>"))
+	return `{"log": {"version": "1.2", "entries": [
+		{
+			"startedDateTime": "2024-01-02T03:04:05.000Z",
+			"request": {"method": "GET", "url": "https://example.com/app"},
+			"response": {
+				"status": 200,
+				"headers": [{"name": "Content-Type", "value": "text/html; charset=windows-1251"}, {"name": "Content-Encoding", "value": "br"}],
+				"content": {"mimeType": "text/html; charset=windows-1251", "text": "<title>Rendered</title><a href=\"/item\">item</a>"}
+			}
+		},
+		{
+			"request": {"method": "GET", "url": "https://example.com/app.js"},
+			"response": {"status": 200, "headers": [], "content": {"mimeType": "application/javascript", "text": "var a;"}}
+		},
+		{
+			"request": {"method": "GET", "url": "https://example.com/redirect"},
+			"response": {"status": 302, "headers": [], "content": {"mimeType": "text/html", "text": ""}}
+		},
+		{
+			"request": {"method": "GET", "url": "https://example.com/cp1251"},
+			"response": {"status": 404, "headers": [], "content": {"mimeType": "text/html; charset=windows-1251", "text": "` + cp1251 + `", "encoding": "base64"}}
+		},
+		{
+			"request": {"method": "GET", "url": "https://example.com/invalid"},
+			"response": {"status": 200, "headers": [], "content": {"mimeType": "text/html", "text": "!!!", "encoding": "base64"}}
+		}
+	]}}`
+}
+
+func Test_ReadHAR(t *testing.T) {
+	result := []string{}
+	err := ReadHAR(strings.NewReader(testHAR()), func(record ArchiveRecord) error {
+		if record.Doc.Err != nil {
+			result = append(result, fmt.Sprintf("%s error", record.URL))
+			return nil
+		}
+
+		title, err := record.Doc.GetDataSingle("title")
+		result = append(result, fmt.Sprintf("%s %d %s", record.URL, record.StatusCode, title))
+		if record.URL == "https://example.com/app" {
+			links, _ := record.Doc.Links()
+			if record.Date.IsZero() || record.Header.Get("Content-Encoding") != "br" || len(links) != 1 || links[0].URL != "https://example.com/item" {
+				t.Errorf("ReadHAR() record: %#v, links: %#v", record, links)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		t.Errorf("ReadHAR() got error: %s", err)
+	}
+
+	expected := []string{"https://example.com/app 200 Rendered", "https://example.com/cp1251 404 Привет", "https://example.com/invalid error"}
+	if !reflect.DeepEqual(expected, result) {
+		t.Errorf("ReadHAR()\nexpected: %#v\nreal    : %#v", expected, result)
+	}
+
+	count := 0
+	err = ReadHAR(strings.NewReader(testHAR()), func(ArchiveRecord) error {
+		count++
+		return ErrStopArchive
+	})
+	if err != nil || count != 1 {
+		t.Errorf("ReadHAR() with ErrStopArchive: got: %d, %v", count, err)
+	}
+
+	if err := ReadHAR(strings.NewReader("not json"), func(ArchiveRecord) error { return nil }); err == nil {
+		t.Errorf("ReadHAR() with invalid HAR: error expected")
+	}
+}
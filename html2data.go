@@ -15,8 +15,17 @@ Allowed pseudo-selectors:
 
 :html - for getting HTML instead text
 
+:outerhtml - for getting HTML of element itself
+
+:cleanhtml - for getting sanitized HTML of element (see Cfg.AllowedTags)
+
 :get(N) - get n-th element from list
 
+:number, :int, :bool, :date(layout) - for getting typed values (see GetDataTyped)
+
+:json(path) - for getting values by path ("props.items.#.name", "$.items[0].name") from JSON in element,
+e.g. <script id="__NEXT_DATA__"> or "window.__STATE__ = {...}"
+
 Command line utility:
 
 	html2data URL "css selector"
@@ -26,12 +35,16 @@ Command line utility:
 package html2data
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/cookiejar"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -49,82 +62,175 @@ type docOrSelection interface {
 type Doc struct {
 	doc docOrSelection
 	Err error
+	URL string // URL of document (after redirects) for resolve relative links, set by FromURL
+
+	jar    http.CookieJar // cookies of session which loaded document, for submit forms
+	parts  []MIMEPart     // other parts of MIME document (images, styles), set by FromMIME
+	xml    bool           // document is parsed from XML by FromReaderXML
+	source *docSource     // source of document for positions of elements in Cfg.Trace
 }
 
 // CSSSelector - selector with settings
 type CSSSelector struct {
-	selector string
-	attrName string
-	getHTML  bool
-	getNth   int
+	selector     string
+	attrName     string
+	getHTML      bool
+	getOuterHTML bool
+	getCleanHTML bool
+	getNth       int
+	getJSON      bool   // parse text as JSON (:json)
+	jsonPath     string // path of values in JSON
+	valueType    string // "number", "int", "bool", "date" or "" for text
+	valueArg     string // decimal separator for number/int, layout for date
 }
 
 // Cfg - config for GetData* methods
 type Cfg struct {
-	DontTrimSpaces bool // get text as is, by default trim spaces
+	DontTrimSpaces   bool                // get text as is, by default trim spaces
+	AllowedTags      map[string][]string // tags with allowed attributes for :cleanhtml, DefaultAllowedTags if nil
+	DecimalSeparator string              // decimal separator for :number/:int ("." or ","), autodetect by default
+	Location         *time.Location      // location for :date without time zone, UTC by default
+	Trace            func(SelectorTrace) // called for each selector with parsed selector and matched nodes, for debug, positions of nodes need ReaderCfg.KeepSource
 }
 
 // getDataFromDocOrSelection - extract data by CSS-selectors from goquery.Selection or goquery.Doc
 func (doc Doc) getDataFromDocOrSelection(docOrSelection docOrSelection, selectors map[string]string, config Cfg) (result map[string][]string, err error) {
+	values, err := doc.getValuesFromDocOrSelection(docOrSelection, selectors, config)
+	if values == nil {
+		return result, err
+	}
+
+	return valuesToTexts(values), err
+}
+
+// getValuesFromDocOrSelection - extract typed values by CSS-selectors from goquery.Selection or goquery.Doc
+func (doc Doc) getValuesFromDocOrSelection(docOrSelection docOrSelection, selectors map[string]string, config Cfg) (result map[string][]interface{}, err error) {
 	if doc.Err != nil {
 		return result, fmt.Errorf("parse document error: %s", doc.Err)
 	}
 	defer func() {
 		if errRecoverRaw := recover(); errRecoverRaw != nil {
-			result, err = map[string][]string{}, fmt.Errorf("%s", errRecoverRaw)
+			result, err = map[string][]interface{}{}, fmt.Errorf("%s", errRecoverRaw)
 		}
 	}()
 
-	result = map[string][]string{}
-	for name, selectorRaw := range selectors {
+	// names are sorted for stable order of errors and traces
+	names := make([]string, 0, len(selectors))
+	for name := range selectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result = map[string][]interface{}{}
+	for _, name := range names {
+		selectorRaw := selectors[name]
 		selector := parseSelector(selectorRaw)
 
-		texts := []string{}
-		docOrSelection.Find(selector.selector).Each(func(i int, selection *goquery.Selection) {
+		var trace *SelectorTrace
+		if config.Trace != nil {
+			trace = doc.newSelectorTrace(name, selectorRaw, selector, docOrSelection)
+		}
+
+		texts := []interface{}{}
+		docOrSelection.Find(doc.findSelector(selector.selector)).Each(func(i int, selection *goquery.Selection) {
 			if selector.getNth > 0 && selector.getNth != i+1 {
+				if trace != nil {
+					trace.addNode(doc, selection.Nodes[0], true, nil)
+				}
 				return
 			}
 
-			var foundText string
-			switch {
-			case selector.attrName != "":
-				foundText = selection.AttrOr(selector.attrName, "")
-			case selector.getHTML:
-				foundText, err = selection.Html()
-				if err != nil {
-					return
-				}
-			default:
-				foundText = selection.Text()
+			values, errValues := doc.selectionValues(selection, selector, config)
+			if errValues != nil {
+				err = errValues
 			}
-
-			if !config.DontTrimSpaces {
-				foundText = strings.TrimSpace(foundText)
+			if trace != nil {
+				trace.addNode(doc, selection.Nodes[0], false, values)
 			}
-			texts = append(texts, foundText)
+			texts = append(texts, values...)
 		})
 		result[name] = texts
+
+		if trace != nil {
+			config.Trace(*trace)
+		}
 	}
 
 	return result, err
 }
 
-var htmlAttrRe = regexp.MustCompile(`^\s*(\w+)\s*(?:\(\s*(\w+)\s*\))?\s*$`)
+// selectionValues - extract typed values from one element found by selector
+func (doc Doc) selectionValues(selection *goquery.Selection, selector CSSSelector, config Cfg) (values []interface{}, err error) {
+	if selector.getJSON {
+		jsonValues, err := jsonValues(selection.Text(), selector.jsonPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range jsonValues {
+			if selector.valueType != "" {
+				value = selector.convertValue(valueToText(value), config)
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	var foundText string
+	switch {
+	case selector.attrName != "":
+		foundText = selection.AttrOr(doc.attrKey(selector.attrName), "")
+	case doc.xml && (selector.getHTML || selector.getOuterHTML):
+		foundText = xmlHTML(selection, selector.getOuterHTML)
+	case selector.getHTML:
+		foundText, err = selection.Html()
+	case selector.getOuterHTML:
+		foundText, err = goquery.OuterHtml(selection)
+	case selector.getCleanHTML:
+		foundText, err = cleanHTML(selection, config.AllowedTags)
+	default:
+		foundText = selection.Text()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.DontTrimSpaces {
+		foundText = strings.TrimSpace(foundText)
+	}
+
+	return []interface{}{selector.convertValue(foundText, config)}, nil
+}
+
+var htmlAttrRe = regexp.MustCompile(`^\s*(\w+)\s*(?:\((.*)\))?\s*$`)
 
 // parseSelector - parse pseudo-selectors:
 // :attr(href) - for getting attribute instead text node
+// :html, :outerhtml, :cleanhtml - for getting HTML instead text node
+// :number, :int, :bool, :date(layout) - for getting typed value
+// :json(path) - for getting values from JSON in element
 func parseSelector(inputSelector string) (outSelector CSSSelector) {
-	parts := strings.Split(inputSelector, ":")
+	parts := splitSelector(inputSelector)
 	outSelector.selector, parts = parts[0], parts[1:]
 	for _, part := range parts {
 		reParts := htmlAttrRe.FindStringSubmatch(part)
+		if len(reParts) == 3 {
+			reParts[2] = strings.TrimSpace(reParts[2])
+		}
 		switch {
 		case len(reParts) == 3 && reParts[1] == "attr":
 			outSelector.attrName = reParts[2]
 		case len(reParts) == 3 && reParts[1] == "html":
 			outSelector.getHTML = true
+		case len(reParts) == 3 && reParts[1] == "outerhtml":
+			outSelector.getOuterHTML = true
+		case len(reParts) == 3 && reParts[1] == "cleanhtml":
+			outSelector.getCleanHTML = true
+		case len(reParts) == 3 && reParts[1] == "json":
+			outSelector.getJSON, outSelector.jsonPath = true, reParts[2]
 		case len(reParts) == 3 && reParts[1] == "get":
 			outSelector.getNth, _ = strconv.Atoi(reParts[2]) // #nosec
+		case len(reParts) == 3 && (reParts[1] == "number" || reParts[1] == "int" || reParts[1] == "bool" || reParts[1] == "date"):
+			outSelector.valueType, outSelector.valueArg = reParts[1], reParts[2]
 		default:
 			outSelector.selector += ":" + part
 		}
@@ -133,6 +239,34 @@ func parseSelector(inputSelector string) (outSelector CSSSelector) {
 	return outSelector
 }
 
+// splitSelector - split selector by ":", but not inside brackets or quotes and not escaped ("\:")
+func splitSelector(inputSelector string) (parts []string) {
+	depth, quote, start, escaped := 0, rune(0), 0, false
+	for i, char := range inputSelector {
+		switch {
+		case escaped:
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		case char == '(' || char == '[':
+			depth++
+		case char == ')' || char == ']':
+			depth--
+		case char == ':' && depth == 0:
+			parts = append(parts, inputSelector[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, inputSelector[start:])
+}
+
 // getConfig - get first config element from list
 func getConfig(configs []Cfg) Cfg {
 	switch {
@@ -174,37 +308,102 @@ func (doc Doc) GetDataFirst(selectors map[string]string, configs ...Cfg) (result
 	return result, err
 }
 
+// GetDataTyped - extract typed values by CSS-selectors, values are float64 for :number,
+// int64 for :int, bool for :bool, time.Time for :date, nil if value not parsed, and string for others
+//
+//	values, err := doc.GetDataTyped(map[string]string{"price": "span.price:number", "date": "time:attr(datetime):date"})
+func (doc Doc) GetDataTyped(selectors map[string]string, configs ...Cfg) (result map[string][]interface{}, err error) {
+	result, err = doc.getValuesFromDocOrSelection(doc.doc, selectors, getConfig(configs))
+	return result, err
+}
+
 // GetDataNested - extract nested data by CSS-selectors from another CSS-selector
 //
 //	texts, err := doc.GetDataNested("CSS.selector", map[string]string{"h1": "h1"}) - get h1 from CSS.selector
 func (doc Doc) GetDataNested(selectorRaw string, nestedSelectors map[string]string, configs ...Cfg) (result []map[string][]string, err error) {
+	values, err := doc.GetDataNestedTyped(selectorRaw, nestedSelectors, configs...)
+	if values == nil {
+		return result, err
+	}
+
+	result = []map[string][]string{}
+	for _, valuesPart := range values {
+		result = append(result, valuesToTexts(valuesPart))
+	}
+
+	return result, err
+}
+
+// GetDataNestedTyped - extract nested typed values by CSS-selectors from another CSS-selector
+//
+//	values, err := doc.GetDataNestedTyped("div.item", map[string]string{"price": "span.price:number"})
+func (doc Doc) GetDataNestedTyped(selectorRaw string, nestedSelectors map[string]string, configs ...Cfg) (result []map[string][]interface{}, err error) {
 	if doc.Err != nil {
 		return result, fmt.Errorf("parse document error: %s", doc.Err)
 	}
 
+	result = []map[string][]interface{}{}
+	err = doc.GetDataNestedTypedFunc(selectorRaw, nestedSelectors, func(item map[string][]interface{}) error {
+		result = append(result, item)
+		return nil
+	}, configs...)
+
+	return result, err
+}
+
+// GetDataNestedFunc - extract nested data by CSS-selectors from another CSS-selector,
+// call fn for each found element instead of collecting all results, error from fn stops extracting
+//
+//	err := doc.GetDataNestedFunc("div.item", map[string]string{"h1": "h1"}, func(item map[string][]string) error {
+//		fmt.Println(item["h1"])
+//		return nil
+//	})
+func (doc Doc) GetDataNestedFunc(selectorRaw string, nestedSelectors map[string]string, fn func(map[string][]string) error, configs ...Cfg) error {
+	return doc.GetDataNestedTypedFunc(selectorRaw, nestedSelectors, func(item map[string][]interface{}) error {
+		return fn(valuesToTexts(item))
+	}, configs...)
+}
+
+// GetDataNestedTypedFunc - extract nested typed values by CSS-selectors from another CSS-selector,
+// call fn for each found element, error from fn stops extracting
+func (doc Doc) GetDataNestedTypedFunc(selectorRaw string, nestedSelectors map[string]string, fn func(map[string][]interface{}) error, configs ...Cfg) (err error) {
+	if doc.Err != nil {
+		return fmt.Errorf("parse document error: %s", doc.Err)
+	}
+
 	selector := parseSelector(selectorRaw)
 	defer func() {
 		if errRecoverRaw := recover(); errRecoverRaw != nil {
-			result, err = []map[string][]string{}, fmt.Errorf("%s", errRecoverRaw)
+			err = fmt.Errorf("%s", errRecoverRaw)
 		}
 	}()
-	result = []map[string][]string{}
 
-	doc.doc.Find(selector.selector).Each(func(i int, selection *goquery.Selection) {
+	config := getConfig(configs)
+	found := doc.doc.Find(doc.findSelector(selector.selector))
+	if config.Trace != nil {
+		trace := doc.newSelectorTrace("", selectorRaw, selector, doc.doc)
+		for i, node := range found.Nodes {
+			trace.addNode(doc, node, selector.getNth > 0 && selector.getNth != i+1, nil)
+		}
+		config.Trace(*trace)
+	}
+
+	found.EachWithBreak(func(i int, selection *goquery.Selection) bool {
 		if selector.getNth > 0 && selector.getNth != i+1 {
-			return
+			return true
 		}
 
-		nestedResult, nestedErr := doc.getDataFromDocOrSelection(selection, nestedSelectors, getConfig(configs))
+		nestedResult, nestedErr := doc.getValuesFromDocOrSelection(selection, nestedSelectors, config)
 		if nestedErr != nil {
 			err = nestedErr
-			return
+			return false
 		}
 
-		result = append(result, nestedResult)
+		err = fn(nestedResult)
+		return err == nil
 	})
 
-	return result, err
+	return err
 }
 
 // GetDataNestedFirst - extract nested data by CSS-selectors from another CSS-selector
@@ -248,20 +447,61 @@ func (doc Doc) GetDataSingle(selector string, configs ...Cfg) (result string, er
 	return result, err
 }
 
-// FromReader - get doc from io.Reader
+// FromReader - get doc from io.Reader, gzip, bzip2 and zstd compressed input is decompressed automatically
 func FromReader(reader io.Reader) Doc {
-	doc, err := goquery.NewDocumentFromReader(reader)
-	return Doc{doc, err}
+	return FromReaderCfg(reader, ReaderCfg{})
+}
+
+// ReaderCfg - config for FromReaderCfg() and FromFileCfg()
+type ReaderCfg struct {
+	XML        bool // parse document as XML (see FromReaderXML)
+	KeepSource bool // keep copy of source for positions (line:column) of elements in Cfg.Trace
+}
+
+// FromReaderCfg - get doc from io.Reader with config
+//
+//	doc := html2data.FromReaderCfg(reader, html2data.ReaderCfg{KeepSource: true})
+func FromReaderCfg(reader io.Reader, config ReaderCfg) Doc {
+	reader, err := decompress(reader)
+	if err != nil {
+		return Doc{Err: err}
+	}
+
+	var source []byte
+	if config.KeepSource {
+		if source, err = io.ReadAll(reader); err != nil {
+			return Doc{Err: err}
+		}
+		reader = bytes.NewReader(source)
+	}
+
+	var doc Doc
+	if config.XML {
+		doc = fromXML(reader)
+	} else {
+		goqueryDoc, err := goquery.NewDocumentFromReader(reader)
+		doc = Doc{doc: goqueryDoc, Err: err}
+	}
+	if config.KeepSource && doc.Err == nil {
+		doc.source = &docSource{data: source, xml: config.XML}
+	}
+
+	return doc
 }
 
 // FromFile - get doc from file
 func FromFile(fileName string) Doc {
+	return FromFileCfg(fileName, ReaderCfg{})
+}
+
+// FromFileCfg - get doc from file with config
+func FromFileCfg(fileName string, config ReaderCfg) Doc {
 	fileReader, err := os.Open(fileName) // #nosec
 	if err != nil {
 		return Doc{Err: err}
 	}
 
-	doc := FromReader(fileReader)
+	doc := FromReaderCfg(fileReader, config)
 	err = fileReader.Close()
 	if err != nil {
 		return Doc{Err: err}
@@ -272,67 +512,189 @@ func FromFile(fileName string) Doc {
 
 // URLCfg - config for FromURL()
 type URLCfg struct {
-	UA                string // custom user-agent
-	TimeOut           int    // timeout in seconds
-	DontDetectCharset bool   // don't autoconvert to UTF8
+	UA                string        // custom user-agent
+	TimeOut           int           // timeout in seconds
+	DontDetectCharset bool          // don't autoconvert to UTF8
+	RespectRobots     bool          // check robots.txt before requests (returns ErrDisallowedByRobots) and wait Crawl-delay
+	CacheDir          string        // directory for cache of GET responses, revalidated by ETag/Last-Modified
+	CacheTTL          time.Duration // use cached responses without revalidation for this time, instead of Cache-Control/Expires
+	RecordDir         string        // directory for record all responses (status, headers, body) for ReplayDir
+	ReplayDir         string        // directory with recorded responses, get responses by method and URL from it without network
+	XML               bool          // parse document as XML (see FromReaderXML)
+	// refuse to connect to loopback, private and link-local addresses (returns ErrPrivateAddress),
+	// resolved address is checked, for fetch URLs from untrusted input
+	DenyPrivateNetworks bool
+	MaxBodySize         int64 // max size of response body in bytes (returns ErrBodyTooLarge), 0 - unlimited
+	KeepSource          bool  // keep copy of source for positions of elements in Cfg.Trace
 }
 
+// ErrBodyTooLarge - error for responses larger than URLCfg.MaxBodySize
+var ErrBodyTooLarge = errors.New("response body is too large")
+
 // FromURL - get doc from URL
 //
 //	FromURL("https://url")
 //	FromURL("https://url", URLCfg{UA: "Custom UA 1.0", TimeOut: 10})
 func FromURL(URL string, config ...URLCfg) Doc {
-	ua, timeout, dontDetectCharset := "", 0, false
-	if len(config) == 1 {
-		ua = config[0].UA
-		timeout = config[0].TimeOut
-		dontDetectCharset = config[0].DontDetectCharset
-	} else if len(config) > 1 {
+	if len(config) > 1 {
 		panic("FromURL(): only one config argument allowed")
 	}
 
-	htmlReader, err := getHTMLPage(URL, ua, timeout, dontDetectCharset)
+	return FromURLContext(context.Background(), URL, config...)
+}
+
+// FromURLContext - get doc from URL, request is cancelled with context
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	doc := html2data.FromURLContext(ctx, "https://url")
+func FromURLContext(ctx context.Context, URL string, config ...URLCfg) Doc {
+	if len(config) > 1 {
+		panic("FromURLContext(): only one config argument allowed")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "GET", URL, nil)
 	if err != nil {
 		return Doc{Err: err}
 	}
 
-	return FromReader(htmlReader)
+	return fromRequest(request, getURLConfig(config), nil)
+}
+
+// getURLConfig - get first URL config element from list
+func getURLConfig(configs []URLCfg) URLCfg {
+	switch {
+	case len(configs) == 0:
+		return URLCfg{}
+	case len(configs) == 1:
+		return configs[0]
+	default:
+		panic("[]URLCfg length must be equal 0 or 1")
+	}
 }
 
-// getHTMLPage - get html by http(s) as http.Response
-func getHTMLPage(url string, ua string, timeout int, dontDetectCharset bool) (htmlReader io.Reader, err error) {
-	cookie, err := cookiejar.New(nil)
+// fromRequest - get doc by http request, cookies are stored in jar (new jar if nil)
+func fromRequest(request *http.Request, config URLCfg, jar http.CookieJar) Doc {
+	if jar == nil {
+		var err error
+		if jar, err = cookiejar.New(nil); err != nil {
+			return Doc{Err: err}
+		}
+	}
+
+	if config.XML {
+		// charset is detected by XML parser
+		config.DontDetectCharset = true
+	}
+
+	htmlReader, finalURL, err := getHTMLPage(request, config, jar)
 	if err != nil {
-		return htmlReader, err
+		return Doc{Err: err}
 	}
 
-	client := &http.Client{
-		Jar:     cookie,
-		Timeout: time.Duration(timeout) * time.Second,
+	doc := FromReaderCfg(htmlReader, ReaderCfg{XML: config.XML, KeepSource: config.KeepSource})
+	doc.URL = finalURL
+	doc.jar = jar
+	return doc
+}
+
+// getHTMLPage - get html by http(s) request, returns final URL after redirects
+func getHTMLPage(request *http.Request, config URLCfg, jar http.CookieJar) (htmlReader io.Reader, finalURL string, err error) {
+	page, err := fetch(request, config, jar)
+	if err != nil {
+		return htmlReader, finalURL, err
 	}
+	finalURL = page.URL
+
+	htmlReader, err = page.htmlReader(config.DontDetectCharset)
+	return htmlReader, finalURL, err
+}
 
-	request, err := http.NewRequest("GET", url, nil)
+// fetchedPage - loaded http response
+type fetchedPage struct {
+	URL        string // final URL after redirects
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// htmlReader - get decoded (Content-Encoding, compression, charset) body of page
+func (page fetchedPage) htmlReader(dontDetectCharset bool) (htmlReader io.Reader, err error) {
+	body, err := decodeContentEncoding(page.Header, page.Body)
 	if err != nil {
-		return htmlReader, err
+		return nil, err
+	}
+	if htmlReader, err = decompress(bytes.NewReader(body)); err != nil {
+		return nil, err
+	}
+
+	if contentType := page.Header.Get("Content-Type"); contentType != "" && !dontDetectCharset {
+		return charset.NewReader(htmlReader, contentType)
+	}
+
+	return htmlReader, nil
+}
+
+// fetch - do http request and read response, or get it from cache or recorded responses
+func fetch(request *http.Request, config URLCfg, jar http.CookieJar) (page fetchedPage, err error) {
+	if config.ReplayDir != "" {
+		return replayPage(config.ReplayDir, request)
+	}
+
+	if config.CacheDir != "" && request.Method == "GET" {
+		page, err = fetchWithCache(request, config, jar)
+	} else {
+		page, err = fetchHTTP(request, config, jar)
+	}
+
+	if err == nil && config.RecordDir != "" {
+		err = recordPage(config.RecordDir, request, page)
+	}
+
+	return page, err
+}
+
+// fetchHTTP - do http request and read response
+func fetchHTTP(request *http.Request, config URLCfg, jar http.CookieJar) (page fetchedPage, err error) {
+	client := newHTTPClient(config, jar)
+
+	if config.UA != "" {
+		request.Header.Set("User-Agent", config.UA)
 	}
 
-	if ua != "" {
-		request.Header.Set("User-Agent", ua)
+	if config.RespectRobots {
+		if err := checkRobots(request.Context(), request.URL, config); err != nil {
+			return page, err
+		}
+		client.CheckRedirect = func(request *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return checkRobots(request.Context(), request.URL, config)
+		}
 	}
 
 	response, err := client.Do(request)
 	if err != nil {
-		return htmlReader, err
+		return page, err
 	}
 
-	if contentType := response.Header.Get("Content-Type"); contentType != "" && !dontDetectCharset {
-		htmlReader, err = charset.NewReader(response.Body, contentType)
-		if err != nil {
-			return htmlReader, err
+	page = fetchedPage{
+		URL:        response.Request.URL.String(),
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+	}
+	if config.MaxBodySize > 0 {
+		page.Body, err = io.ReadAll(io.LimitReader(response.Body, config.MaxBodySize+1))
+		if err == nil && int64(len(page.Body)) > config.MaxBodySize {
+			err = fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, config.MaxBodySize)
 		}
 	} else {
-		return response.Body, nil
+		page.Body, err = io.ReadAll(response.Body)
+	}
+	if errClose := response.Body.Close(); err == nil {
+		err = errClose
 	}
 
-	return htmlReader, nil
+	return page, err
 }
@@ -52,6 +52,16 @@ func Test_GetDataSingle(t *testing.T) {
 			"h1:get(2)",
 			"head2",
 			nil,
+		}, {
+			"one<div><h1>head</h1>two</div><h1 id=2>head2</h1>",
+			"div:outerhtml",
+			"<div><h1>head</h1>two</div>",
+			nil,
+		}, {
+			"one<div onclick='x()'><h1>head</h1><script>alert(1)</script>two</div>",
+			"div:cleanhtml",
+			"<div><h1>head</h1>two</div>",
+			nil,
 		}, {
 			"<div>",
 			"div<<<",
@@ -293,6 +303,29 @@ func Test_GetDataNested(t *testing.T) {
 	}
 }
 
+func Test_GetDataNestedFunc(t *testing.T) {
+	doc := FromReader(strings.NewReader("<div><h1>head1</h1></div><div><h1>head2</h1></div><div><h1>head3</h1></div>"))
+
+	items := []map[string][]string{}
+	err := doc.GetDataNestedFunc("div", map[string]string{"h1": "h1"}, func(item map[string][]string) error {
+		items = append(items, item)
+		if len(items) == 2 {
+			return fmt.Errorf("stop")
+		}
+		return nil
+	})
+
+	expected := []map[string][]string{{"h1": {"head1"}}, {"h1": {"head2"}}}
+	if err == nil || err.Error() != "stop" || !reflect.DeepEqual(expected, items) {
+		t.Errorf("GetDataNestedFunc() got: %#v, %v", items, err)
+	}
+
+	err = FromFile("/dont exists file").GetDataNestedFunc("div", map[string]string{}, func(map[string][]string) error { return nil })
+	if err == nil {
+		t.Errorf("GetDataNestedFunc() on document with error without error")
+	}
+}
+
 func Test_GetDataNestedFirst(t *testing.T) {
 	testData := []struct {
 		html     string
@@ -349,66 +382,73 @@ func Test_parseSelector(t *testing.T) {
 		{
 			"div",
 			CSSSelector{
-				"div",
-				"",
-				false,
-				0,
+				selector: "div",
 			},
 		}, {
 			"div:attr(href)",
 			CSSSelector{
-				"div",
-				"href",
-				false,
-				0,
+				selector: "div",
+				attrName: "href",
 			},
 		}, {
 			"div: attr ( href ) ",
 			CSSSelector{
-				"div",
-				"href",
-				false,
-				0,
+				selector: "div",
+				attrName: "href",
 			},
 		}, {
 			"div#1: attr ( href ) ",
 			CSSSelector{
-				"div#1",
-				"href",
-				false,
-				0,
+				selector: "div#1",
+				attrName: "href",
 			},
 		}, {
 			"div#1:html",
 			CSSSelector{
-				"div#1",
-				"",
-				true,
-				0,
+				selector: "div#1",
+				getHTML:  true,
 			},
 		}, {
 			"div#1",
 			CSSSelector{
-				"div#1",
-				"",
-				false,
-				0,
+				selector: "div#1",
 			},
 		}, {
 			"div:nth-child(1):attr(href)",
 			CSSSelector{
-				"div:nth-child(1)",
-				"href",
-				false,
-				0,
+				selector: "div:nth-child(1)",
+				attrName: "href",
+			},
+		}, {
+			"div:outerhtml",
+			CSSSelector{
+				selector:     "div",
+				getOuterHTML: true,
+			},
+		}, {
+			"div:cleanhtml",
+			CSSSelector{
+				selector:     "div",
+				getCleanHTML: true,
 			},
 		}, {
 			"div:nth-child(1):get(3)",
 			CSSSelector{
-				"div:nth-child(1)",
-				"",
-				false,
-				3,
+				selector: "div:nth-child(1)",
+				getNth:   3,
+			},
+		}, {
+			"script#data:json(props.items.#.name)",
+			CSSSelector{
+				selector: "script#data",
+				getJSON:  true,
+				jsonPath: "props.items.#.name",
+			},
+		}, {
+			`media\:content:attr(url)`,
+			CSSSelector{
+				selector: `media\:content`,
+				attrName: "url",
 			},
 		},
 	}
@@ -0,0 +1,142 @@
+package html2data
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// jsAssignmentRe - JS assignment before JSON: "window.__STATE__ = ", "var data = "
+var jsAssignmentRe = regexp.MustCompile(`^(?:(?:var|let|const)\s+)?[\w$.\[\]'"]+\s*=\s*`)
+
+// jsonValues - parse JSON from text of element (JS assignment prefix is stripped)
+// and get values by path, arrays are returned as list of values, objects as JSON strings
+func jsonValues(text string, path string) ([]interface{}, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "{") && !strings.HasPrefix(text, "[") {
+		if loc := jsAssignmentRe.FindStringIndex(text); loc != nil {
+			text = text[loc[1]:]
+		}
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	// the rest of script after JSON value is ignored
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("parse JSON: %s", err)
+	}
+
+	value, ok := jsonPathValue(value, splitJSONPath(path))
+	if !ok {
+		return []interface{}{}, nil
+	}
+
+	list, isList := value.([]interface{})
+	if !isList {
+		list = []interface{}{value}
+	}
+
+	result := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		item, err := jsonScalar(item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// splitJSONPath - split path "a.b.0.c", "a.#.b" (gjson-like) or "$.a.b[0].c", "$.a[*].b" (JSONPath-like) to keys,
+// "\." for dots in keys
+func splitJSONPath(path string) (keys []string) {
+	path = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(path), "$"), ".")
+	if path == "" {
+		return nil
+	}
+
+	key := strings.Builder{}
+	escaped := false
+	for _, char := range path {
+		switch {
+		case escaped:
+			key.WriteRune(char)
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '.' || char == '[':
+			if key.Len() > 0 {
+				keys = append(keys, key.String())
+			}
+			key.Reset()
+		case char == ']':
+		default:
+			key.WriteRune(char)
+		}
+	}
+	if key.Len() > 0 {
+		keys = append(keys, key.String())
+	}
+
+	return keys
+}
+
+// jsonPathValue - get value by keys: object key, array index,
+// "#" - all elements of array (or count of elements if it is the last key, as in gjson),
+// "*" - all elements of array (JSONPath)
+func jsonPathValue(value interface{}, keys []string) (interface{}, bool) {
+	for i, key := range keys {
+		switch current := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = current[key]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			if key == "#" || key == "*" {
+				if key == "#" && i == len(keys)-1 {
+					return int64(len(current)), true
+				}
+
+				result := []interface{}{}
+				for _, item := range current {
+					if itemValue, ok := jsonPathValue(item, keys[i+1:]); ok {
+						result = append(result, itemValue)
+					}
+				}
+				return result, true
+			}
+
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false
+			}
+			value = current[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// jsonScalar - convert JSON value to typed value: numbers to int64/float64, objects and arrays to JSON string
+func jsonScalar(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case json.Number:
+		if number, err := value.Int64(); err == nil {
+			return number, nil
+		}
+		return value.Float64()
+	case map[string]interface{}, []interface{}:
+		jsonBytes, err := json.Marshal(value)
+		return string(jsonBytes), err
+	default:
+		// string, bool, nil
+		return value, nil
+	}
+}
@@ -0,0 +1,97 @@
+package html2data
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_splitJSONPath(t *testing.T) {
+	testData := map[string][]string{
+		"":            nil,
+		"$":           nil,
+		"a.b.0.c":     {"a", "b", "0", "c"},
+		"a.#.b":       {"a", "#", "b"},
+		"$.a.b[0].c":  {"a", "b", "0", "c"},
+		"$.a[*].b":    {"a", "*", "b"},
+		`a\.b.c`:      {"a.b", "c"},
+		" $.items.# ": {"items", "#"},
+	}
+
+	for path, expected := range testData {
+		if keys := splitJSONPath(path); !reflect.DeepEqual(expected, keys) {
+			t.Errorf("splitJSONPath(%q): expected: %#v, got: %#v", path, expected, keys)
+		}
+	}
+}
+
+func Test_jsonValues(t *testing.T) {
+	state := `{"props": {"title": "Page", "count": 2, "price": 9.5, "ok": true, "none": null,
+		"items": [{"name": "one", "tags": ["a"]}, {"name": "two"}], "a.b": "dot"}}`
+
+	testData := []struct {
+		text     string
+		path     string
+		expected []interface{}
+		err      bool
+	}{
+		{text: state, path: "props.title", expected: []interface{}{"Page"}},
+		{text: state, path: "props.count", expected: []interface{}{int64(2)}},
+		{text: state, path: "props.price", expected: []interface{}{9.5}},
+		{text: state, path: "props.ok", expected: []interface{}{true}},
+		{text: state, path: "props.none", expected: []interface{}{nil}},
+		{text: state, path: "props.items.#.name", expected: []interface{}{"one", "two"}},
+		{text: state, path: "$.props.items[1].name", expected: []interface{}{"two"}},
+		{text: state, path: "props.items.#", expected: []interface{}{int64(2)}},
+		{text: state, path: "$.props.items[*].name", expected: []interface{}{"one", "two"}},
+		{text: state, path: "$.props.items[0].tags[*]", expected: []interface{}{"a"}},
+		{text: `{"a": [1, 2, 3]}`, path: "$.a[*]", expected: []interface{}{int64(1), int64(2), int64(3)}},
+		{text: state, path: "props.items.0.tags", expected: []interface{}{"a"}},
+		{text: state, path: "props.items.0", expected: []interface{}{`{"name":"one","tags":["a"]}`}},
+		{text: state, path: `props.a\.b`, expected: []interface{}{"dot"}},
+		{text: state, path: "props.unknown", expected: []interface{}{}},
+		{text: state, path: "props.items.5.name", expected: []interface{}{}},
+		{text: state, path: "props.title.x", expected: []interface{}{}},
+		{text: "window.__STATE__ = " + state + ";\nwindow.x = 1;", path: "props.title", expected: []interface{}{"Page"}},
+		{text: `var data = [1, 2]`, path: "", expected: []interface{}{int64(1), int64(2)}},
+		{text: `window["data"]={"a": "b"}`, path: "a", expected: []interface{}{"b"}},
+		{text: "not json", path: "a", err: true},
+	}
+
+	for i, item := range testData {
+		values, err := jsonValues(item.text, item.path)
+		if (err != nil) != item.err || !item.err && !reflect.DeepEqual(item.expected, values) {
+			t.Errorf("%d. jsonValues(%q)\nexpected: %#v\nreal    : %#v, %v", i, item.path, item.expected, values, err)
+		}
+	}
+}
+
+func Test_GetDataJSON(t *testing.T) {
+	doc := FromReader(strings.NewReader(`<html><body>
+		<script id="__NEXT_DATA__" type="application/json">{"props": {"items": [{"name": "one", "price": "1,5"}, {"name": "two", "price": "2"}]}}</script>
+		<script>window.__STATE__ = {"user": {"id": 42}};</script>
+		<p>not json</p>
+	</body></html>`))
+
+	texts, err := doc.GetData(map[string]string{
+		"names": "#__NEXT_DATA__:json(props.items.#.name)",
+		"id":    `script:contains("__STATE__"):json(user.id)`,
+	})
+	expected := map[string][]string{"names": {"one", "two"}, "id": {"42"}}
+	if err != nil || !reflect.DeepEqual(expected, texts) {
+		t.Errorf("GetData() with :json\nexpected: %#v\nreal    : %#v, %v", expected, texts, err)
+	}
+
+	values, err := doc.GetDataTyped(map[string]string{
+		"id":     `script:contains("__STATE__"):json(user.id)`,
+		"prices": "#__NEXT_DATA__:json(props.items.#.price):number(,)",
+	})
+	expectedValues := map[string][]interface{}{"id": {int64(42)}, "prices": {1.5, 2.0}}
+	if err != nil || !reflect.DeepEqual(expectedValues, values) {
+		t.Errorf("GetDataTyped() with :json\nexpected: %#v\nreal    : %#v, %v", expectedValues, values, err)
+	}
+
+	if _, err := doc.GetData(map[string]string{"bad": "p:json(a)"}); err == nil {
+		t.Errorf("GetData() with :json for not JSON: error expected")
+	}
+}
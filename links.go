@@ -0,0 +1,229 @@
+package html2data
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Link - outgoing link of document, result of Links()
+type Link struct {
+	URL      string `json:"url"` // absolute URL if document URL is known
+	Text     string `json:"text"`
+	Title    string `json:"title,omitempty"`
+	Rel      string `json:"rel,omitempty"`
+	Internal bool   `json:"internal"` // link to the same host as document
+}
+
+// Asset - resource used by document, result of Assets()
+type Asset struct {
+	URL      string `json:"url"`  // absolute URL if document URL is known
+	Type     string `json:"type"` // image, script, stylesheet, icon, iframe, video, audio
+	Tag      string `json:"tag"`
+	Internal bool   `json:"internal"` // asset from the same host as document
+}
+
+// assetSelectors - selectors for assets with type of asset, srcset attributes can contain list of URLs
+var assetSelectors = []struct {
+	selector  string
+	assetType string
+}{
+	{"img:attr(src)", "image"},
+	{"img:attr(srcset)", "image"},
+	{"picture source:attr(srcset)", "image"},
+	{"input[type=image]:attr(src)", "image"},
+	{"script:attr(src)", "script"},
+	{`link[rel~="stylesheet"]:attr(href)`, "stylesheet"},
+	{`link[rel~="icon"]:attr(href)`, "icon"},
+	{`link[rel="apple-touch-icon"]:attr(href)`, "icon"},
+	{"iframe:attr(src)", "iframe"},
+	{"frame:attr(src)", "iframe"},
+	{"video:attr(src)", "video"},
+	{"video:attr(poster)", "image"},
+	{"video source:attr(src)", "video"},
+	{"audio:attr(src)", "audio"},
+	{"audio source:attr(src)", "audio"},
+	{"embed:attr(src)", "embed"},
+	{"object:attr(data)", "embed"},
+}
+
+// Links - get all links (<a href>, <area href>) of document with absolute URLs
+//
+//	links, err := html2data.FromURL("http://example.com").Links()
+func (doc Doc) Links() (result []Link, err error) {
+	if doc.Err != nil {
+		return result, fmt.Errorf("parse document error: %s", doc.Err)
+	}
+
+	base, err := doc.baseURL()
+	if err != nil {
+		return result, err
+	}
+
+	result = []Link{}
+	doc.doc.Find("a[href], area[href]").Each(func(_ int, selection *goquery.Selection) {
+		linkURL, ok := resolveURL(base, selection.AttrOr("href", ""))
+		if !ok {
+			return
+		}
+
+		result = append(result, Link{
+			URL:      linkURL.String(),
+			Text:     normalizeSpaces(selection.Text()),
+			Title:    selection.AttrOr("title", ""),
+			Rel:      selection.AttrOr("rel", ""),
+			Internal: isInternalURL(base, linkURL),
+		})
+	})
+
+	return result, nil
+}
+
+// Assets - get all images, scripts, stylesheets, iframes and media of document with absolute URLs,
+// each URL is returned once
+//
+//	assets, err := html2data.FromURL("http://example.com").Assets()
+func (doc Doc) Assets() (result []Asset, err error) {
+	if doc.Err != nil {
+		return result, fmt.Errorf("parse document error: %s", doc.Err)
+	}
+
+	base, err := doc.baseURL()
+	if err != nil {
+		return result, err
+	}
+
+	result = []Asset{}
+	seen := map[string]bool{}
+	for _, item := range assetSelectors {
+		selector := parseSelector(item.selector)
+		doc.doc.Find(selector.selector).Each(func(_ int, selection *goquery.Selection) {
+			value, ok := selection.Attr(selector.attrName)
+			if !ok {
+				return
+			}
+
+			rawURLs := []string{value}
+			if selector.attrName == "srcset" {
+				rawURLs = parseSrcset(value)
+			}
+
+			for _, rawURL := range rawURLs {
+				assetURL, ok := resolveURL(base, rawURL)
+				if !ok || seen[item.assetType+" "+assetURL.String()] {
+					continue
+				}
+				seen[item.assetType+" "+assetURL.String()] = true
+
+				result = append(result, Asset{
+					URL:      assetURL.String(),
+					Type:     item.assetType,
+					Tag:      goquery.NodeName(selection),
+					Internal: isInternalURL(base, assetURL),
+				})
+			}
+		})
+	}
+
+	return result, nil
+}
+
+// baseURL - URL of document for resolve relative links, with <base href> if exists,
+// nil if URL of document is unknown
+func (doc Doc) baseURL() (*url.URL, error) {
+	var base *url.URL
+	if doc.URL != "" {
+		docURL, err := url.Parse(doc.URL)
+		if err != nil {
+			return nil, err
+		}
+		base = docURL
+	}
+
+	if baseHref, ok := doc.doc.Find("base[href]").First().Attr("href"); ok {
+		if baseURL, ok := resolveURL(base, baseHref); ok {
+			base = baseURL
+		}
+	}
+
+	return base, nil
+}
+
+// resolveURL - get absolute URL by base URL, returns false for empty and javascript: URLs
+func resolveURL(base *url.URL, rawURL string) (*url.URL, bool) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" || strings.HasPrefix(strings.ToLower(rawURL), "javascript:") {
+		return nil, false
+	}
+
+	result, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, false
+	}
+
+	if base != nil {
+		result = base.ResolveReference(result)
+	}
+
+	return result, true
+}
+
+// isInternalURL - check that URL is on the same host as base URL, relative URLs are internal
+func isInternalURL(base *url.URL, checkedURL *url.URL) bool {
+	if checkedURL.Scheme != "" && checkedURL.Scheme != "http" && checkedURL.Scheme != "https" {
+		return false
+	}
+	if checkedURL.Host == "" {
+		return true
+	}
+	if base == nil {
+		return false
+	}
+
+	return strings.EqualFold(base.Hostname(), checkedURL.Hostname())
+}
+
+// parseSrcset - get URLs from srcset attribute: "1.png 1x, 2.png 2x", as in HTML spec:
+// URL is read up to whitespace (so it can contain commas, like data: URLs), then descriptors up to comma
+func parseSrcset(srcset string) (result []string) {
+	isSpace := func(char byte) bool {
+		return char == ' ' || char == '\t' || char == '\n' || char == '\r' || char == '\f'
+	}
+
+	for i := 0; i < len(srcset); {
+		// skip whitespace and commas before candidate
+		if isSpace(srcset[i]) || srcset[i] == ',' {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(srcset) && !isSpace(srcset[i]) {
+			i++
+		}
+		candidateURL := srcset[start:i]
+		if strings.HasSuffix(candidateURL, ",") {
+			// comma at the end of URL ends candidate without descriptors
+			if candidateURL = strings.TrimRight(candidateURL, ","); candidateURL != "" {
+				result = append(result, candidateURL)
+			}
+			continue
+		}
+		result = append(result, candidateURL)
+
+		// skip descriptors up to comma outside of parentheses
+		inParens := false
+		for ; i < len(srcset) && (srcset[i] != ',' || inParens); i++ {
+			switch srcset[i] {
+			case '(':
+				inParens = true
+			case ')':
+				inParens = false
+			}
+		}
+	}
+
+	return result
+}
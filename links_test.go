@@ -0,0 +1,103 @@
+package html2data
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const linksTestHTML = `<html><head>
+<link rel="stylesheet" href="/css/main.css"><link rel="icon" href="favicon.ico">
+<script src="https://cdn.example.org/lib.js"></script><script>inline()</script>
+</head><body>
+<a href="/about" rel="nofollow" title="About us"> About
+	us </a>
+<a href="https://other.org/page">Other</a>
+<a href="javascript:void(0)">JS</a>
+<a href="mailto:user@example.com">Mail</a>
+<img src="img/1.png" srcset="img/1.png 1x, img/2.png 2x">
+<iframe src="//video.example.org/embed/1"></iframe>
+</body></html>`
+
+func Test_Links(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, linksTestHTML)
+	}))
+	defer ts.Close()
+
+	doc := FromURL(ts.URL + "/dir/page.html")
+	links, err := doc.Links()
+	if err != nil {
+		t.Fatalf("Links() got error: %s", err)
+	}
+
+	expected := []Link{
+		{URL: ts.URL + "/about", Text: "About us", Title: "About us", Rel: "nofollow", Internal: true},
+		{URL: "https://other.org/page", Text: "Other"},
+		{URL: "mailto:user@example.com", Text: "Mail"},
+	}
+	if !reflect.DeepEqual(expected, links) {
+		t.Errorf("Links()\nexpected: %#v\nreal    : %#v", expected, links)
+	}
+
+	assets, err := doc.Assets()
+	if err != nil {
+		t.Fatalf("Assets() got error: %s", err)
+	}
+
+	expectedAssets := []Asset{
+		{URL: ts.URL + "/dir/img/1.png", Type: "image", Tag: "img", Internal: true},
+		{URL: ts.URL + "/dir/img/2.png", Type: "image", Tag: "img", Internal: true},
+		{URL: "https://cdn.example.org/lib.js", Type: "script", Tag: "script"},
+		{URL: ts.URL + "/css/main.css", Type: "stylesheet", Tag: "link", Internal: true},
+		{URL: ts.URL + "/dir/favicon.ico", Type: "icon", Tag: "link", Internal: true},
+		{URL: "http://video.example.org/embed/1", Type: "iframe", Tag: "iframe"},
+	}
+	if !reflect.DeepEqual(expectedAssets, assets) {
+		t.Errorf("Assets()\nexpected: %#v\nreal    : %#v", expectedAssets, assets)
+	}
+}
+
+func Test_LinksWithoutURL(t *testing.T) {
+	links, err := FromReader(strings.NewReader(`<a href="/about">About</a><a href="http://url">url</a>`)).Links()
+	expected := []Link{{URL: "/about", Text: "About", Internal: true}, {URL: "http://url", Text: "url"}}
+	if err != nil || !reflect.DeepEqual(expected, links) {
+		t.Errorf("Links() without URL\nexpected: %#v\nreal    : %#v", expected, links)
+	}
+
+	links, err = FromReader(strings.NewReader(`<base href="http://site/dir/"><a href="page">Page</a>`)).Links()
+	expected = []Link{{URL: "http://site/dir/page", Text: "Page", Internal: true}}
+	if err != nil || !reflect.DeepEqual(expected, links) {
+		t.Errorf("Links() with <base>\nexpected: %#v\nreal    : %#v", expected, links)
+	}
+
+	if _, err := FromFile("/dont exists file").Links(); err == nil {
+		t.Errorf("Links() on document with error without error")
+	}
+}
+
+func Test_parseSrcset(t *testing.T) {
+	testData := []struct {
+		srcset   string
+		expected []string
+	}{
+		{"1.png", []string{"1.png"}},
+		{"img/1.png 1x, img/2.png 2x", []string{"img/1.png", "img/2.png"}},
+		{"  a.png  100w ,b.png 200w,  ", []string{"a.png", "b.png"}},
+		{"a.png,, b.png 2x", []string{"a.png", "b.png"}},
+		{"a,b.png 1x, c.png 2x", []string{"a,b.png", "c.png"}},
+		{"data:image/png;base64,iVBOR= 1x, 2.png 2x", []string{"data:image/png;base64,iVBOR=", "2.png"}},
+		{"1.png future-descriptor(1, 2), 2.png", []string{"1.png", "2.png"}},
+		{"", nil},
+		{" , ,", nil},
+	}
+
+	for i, item := range testData {
+		if real := parseSrcset(item.srcset); !reflect.DeepEqual(real, item.expected) {
+			t.Errorf("%d. parseSrcset(%q): expected: %q, real: %q", i, item.srcset, item.expected, real)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package html2data
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Match - element found by selector, result of Doc.Matches()
+type Match struct {
+	Path   string        // DOM path of element: "html > body > div#main > p.note:nth-of-type(2)"
+	Values []interface{} // values extracted by pseudo-selectors from element
+}
+
+// Matches - find elements by CSS-selector with pseudo-selectors, get DOM path and values for each element
+//
+//	matches, err := doc.Matches("div.article a:attr(href)")
+//	for _, match := range matches {
+//		fmt.Println(match.Path, match.Values)
+//	}
+func (doc Doc) Matches(selectorRaw string, configs ...Cfg) (result []Match, err error) {
+	if doc.Err != nil {
+		return nil, fmt.Errorf("parse document error: %s", doc.Err)
+	}
+	defer func() {
+		if errRecoverRaw := recover(); errRecoverRaw != nil {
+			result, err = nil, fmt.Errorf("%s", errRecoverRaw)
+		}
+	}()
+
+	config := getConfig(configs)
+	selector := parseSelector(selectorRaw)
+	result = []Match{}
+	doc.doc.Find(doc.findSelector(selector.selector)).Each(func(i int, selection *goquery.Selection) {
+		if err != nil || selector.getNth > 0 && selector.getNth != i+1 {
+			return
+		}
+
+		var values []interface{}
+		values, err = doc.selectionValues(selection, selector, config)
+		result = append(result, Match{Path: doc.nodePath(selection.Nodes[0]), Values: values})
+	})
+
+	return result, err
+}
+
+// nodePath - DOM path of element from root, with id, classes and position among siblings with the same name
+func (doc Doc) nodePath(node *html.Node) string {
+	parts := []string{}
+	for ; node != nil && node.Type == html.ElementNode; node = node.Parent {
+		name := node.Data
+		if doc.xml {
+			name = decodeXMLName(name)
+		}
+
+		part := strings.Builder{}
+		part.WriteString(name)
+		for _, attr := range node.Attr {
+			switch {
+			case attr.Key == "id" && strings.TrimSpace(attr.Val) != "":
+				part.WriteString("#" + strings.TrimSpace(attr.Val))
+			case attr.Key == "class" && !doc.xml:
+				for _, class := range strings.Fields(attr.Val) {
+					part.WriteString("." + class)
+				}
+			}
+		}
+
+		position, count := 0, 0
+		if node.Parent != nil {
+			for sibling := node.Parent.FirstChild; sibling != nil; sibling = sibling.NextSibling {
+				if sibling.Type == html.ElementNode && sibling.Data == node.Data {
+					count++
+					if sibling == node {
+						position = count
+					}
+				}
+			}
+		}
+		if count > 1 {
+			part.WriteString(fmt.Sprintf(":nth-of-type(%d)", position))
+		}
+
+		parts = append([]string{part.String()}, parts...)
+	}
+
+	return strings.Join(parts, " > ")
+}
@@ -0,0 +1,53 @@
+package html2data
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Matches(t *testing.T) {
+	doc := FromReader(strings.NewReader(`<div id="main"><p class="note first">1</p><p>2</p><a href="/x">link</a></div>`))
+
+	testData := []struct {
+		selector string
+		expected []Match
+	}{
+		{
+			selector: "p",
+			expected: []Match{
+				{Path: "html > body > div#main > p.note.first:nth-of-type(1)", Values: []interface{}{"1"}},
+				{Path: "html > body > div#main > p:nth-of-type(2)", Values: []interface{}{"2"}},
+			},
+		},
+		{
+			selector: "p:get(2):int",
+			expected: []Match{
+				{Path: "html > body > div#main > p:nth-of-type(2)", Values: []interface{}{int64(2)}},
+			},
+		},
+		{
+			selector: "a:attr(href)",
+			expected: []Match{
+				{Path: "html > body > div#main > a", Values: []interface{}{"/x"}},
+			},
+		},
+		{
+			selector: "h1",
+			expected: []Match{},
+		},
+	}
+
+	for i, item := range testData {
+		matches, err := doc.Matches(item.selector)
+		if err != nil || !reflect.DeepEqual(matches, item.expected) {
+			t.Errorf("%d. Matches(%q): expected: %#v\nreal: %#v, %v", i, item.selector, item.expected, matches, err)
+		}
+	}
+
+	xmlDoc := FromReaderXML(strings.NewReader(`<rss><channel><item><pubDate>today</pubDate></item></channel></rss>`))
+	matches, err := xmlDoc.Matches("pubDate")
+	if err != nil || len(matches) != 1 || matches[0].Path != "rss > channel > item > pubDate" {
+		t.Errorf("Matches() for XML: got: %#v, %v", matches, err)
+	}
+}
@@ -0,0 +1,148 @@
+package html2data
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// MIMEPart - decoded part of MIME document (MHTML, EML)
+type MIMEPart struct {
+	ContentType string               // media type with parameters
+	ContentID   string               // Content-ID without angle brackets, for "cid:" references
+	Location    string               // Content-Location, URL of resource in MHTML
+	Header      textproto.MIMEHeader // all headers of part
+	Body        []byte               // body decoded from base64/quoted-printable
+}
+
+// FromMIME - get doc from MIME document: saved web page (.mhtml) or email (.eml),
+// the first text/html part is parsed as document, other parts are available by doc.Parts() and doc.Part()
+func FromMIME(reader io.Reader) Doc {
+	reader, err := decompress(reader)
+	if err != nil {
+		return Doc{Err: err}
+	}
+
+	message, err := mail.ReadMessage(reader)
+	if err != nil {
+		return Doc{Err: fmt.Errorf("read MIME message: %s", err)}
+	}
+
+	parts, err := readMIMEParts(textproto.MIMEHeader(message.Header), message.Body)
+	if err != nil {
+		return Doc{Err: err}
+	}
+
+	htmlIndex := -1
+	for i, part := range parts {
+		if isHTMLContentType(part.ContentType) {
+			htmlIndex = i
+			break
+		}
+	}
+	if htmlIndex == -1 {
+		return Doc{Err: errors.New("text/html part is not found in MIME message")}
+	}
+	htmlPart := parts[htmlIndex]
+
+	htmlReader, err := charset.NewReader(bytes.NewReader(htmlPart.Body), htmlPart.ContentType)
+	if err != nil {
+		return Doc{Err: err}
+	}
+
+	doc := FromReader(htmlReader)
+	doc.URL = htmlPart.Location
+	doc.parts = append(parts[:htmlIndex:htmlIndex], parts[htmlIndex+1:]...)
+	return doc
+}
+
+// readMIMEParts - read leaf parts of message or part recursively
+func readMIMEParts(header textproto.MIMEHeader, body io.Reader) (parts []MIMEPart, err error) {
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain; charset=us-ascii"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("parse Content-Type %q: %s", contentType, err)
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		multipartReader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := multipartReader.NextRawPart()
+			if err == io.EOF {
+				return parts, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("read MIME part: %s", err)
+			}
+
+			nested, err := readMIMEParts(part.Header, part)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, nested...)
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("decode MIME part: %s", err)
+	}
+
+	return []MIMEPart{{
+		ContentType: contentType,
+		ContentID:   strings.Trim(header.Get("Content-ID"), "<> "),
+		Location:    header.Get("Content-Location"),
+		Header:      header,
+		Body:        content,
+	}}, nil
+}
+
+// Parts - get other parts of MIME document (created by FromMIME), except HTML part of document
+func (doc Doc) Parts() []MIMEPart {
+	return doc.parts
+}
+
+// Part - get part of MIME document by reference from document: "cid:..." or URL from Content-Location
+//
+//	src, _ := doc.GetDataSingle("img.logo:attr(src)")
+//	if part, ok := doc.Part(src); ok {
+//		os.WriteFile("logo.png", part.Body, 0o644)
+//	}
+func (doc Doc) Part(ref string) (MIMEPart, bool) {
+	contentID, isCID := "", strings.HasPrefix(strings.ToLower(ref), "cid:")
+	if isCID {
+		contentID = strings.Trim(ref[len("cid:"):], "<> ")
+		if unescaped, err := url.PathUnescape(contentID); err == nil {
+			contentID = unescaped
+		}
+	}
+
+	for _, part := range doc.parts {
+		if isCID && part.ContentID == contentID || !isCID && part.Location != "" && part.Location == ref {
+			return part, true
+		}
+	}
+
+	return MIMEPart{}, false
+}
@@ -0,0 +1,102 @@
+package html2data
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+const testMHTML = "From: <Saved by Blink>\r\n" +
+	"Snapshot-Content-Location: https://example.com/page\r\n" +
+	"Subject: Page\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/related;\r\n" +
+	"\ttype=\"text/html\";\r\n" +
+	"\tboundary=\"----MultipartBoundary--abc----\"\r\n" +
+	"\r\n" +
+	"------MultipartBoundary--abc----\r\n" +
+	"Content-Type: text/html\r\n" +
+	"Content-ID: <frame-1@mhtml.blink>\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"Content-Location: https://example.com/page\r\n" +
+	"\r\n" +
+	"<html><head><meta charset=3D\"utf-8\"><title>Saved page</title></head><body><a href=3D\"/abo=\r\n" +
+	"ut\">About</a><img class=3D\"logo\" src=3D\"https://example.com/logo.png\"></body></html>\r\n" +
+	"------MultipartBoundary--abc----\r\n" +
+	"Content-Type: image/png\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"Content-Location: https://example.com/logo.png\r\n" +
+	"\r\n" +
+	"iVBORw0K\r\n" +
+	"------MultipartBoundary--abc------\r\n"
+
+func testEML() string {
+	// "Привет" in windows-1251
+	html := base64.StdEncoding.EncodeToString([]byte("<p>\xcf\xf0\xe8\xe2\xe5\xf2</p><img src=\"cid:logo%40example\">"))
+	return "From: shop@example.com\r\n" +
+		"Subject: Order\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/related; boundary=\"outer\"\r\n" +
+		"\r\n" +
+		"--outer\r\n" +
+		"Content-Type: multipart/alternative; boundary=\"inner\"\r\n" +
+		"\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"\r\n" +
+		"Plain text\r\n" +
+		"--inner\r\n" +
+		"Content-Type: text/html; charset=windows-1251\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		html[:20] + "\r\n" + html[20:] + "\r\n" +
+		"--inner--\r\n" +
+		"--outer\r\n" +
+		"Content-Type: image/gif\r\n" +
+		"Content-ID: <logo@example>\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		"R0lGODlh\r\n" +
+		"--outer--\r\n"
+}
+
+func Test_FromMIME(t *testing.T) {
+	doc := FromMIME(strings.NewReader(testMHTML))
+	if doc.Err != nil {
+		t.Fatalf("FromMIME(mhtml) got error: %s", doc.Err)
+	}
+	if title, err := doc.GetDataSingle("title"); err != nil || title != "Saved page" {
+		t.Errorf("FromMIME(mhtml) title: %q, %v", title, err)
+	}
+	if links, err := doc.Links(); err != nil || len(links) != 1 || links[0].URL != "https://example.com/about" {
+		t.Errorf("FromMIME(mhtml) links: %#v, %v", links, err)
+	}
+	src, _ := doc.GetDataSingle("img.logo:attr(src)")
+	if part, ok := doc.Part(src); !ok || part.ContentType != "image/png" || string(part.Body) != "\x89PNG\r\n" {
+		t.Errorf("FromMIME(mhtml) Part(%q): %#v, %v", src, part, ok)
+	}
+	if len(doc.Parts()) != 1 {
+		t.Errorf("FromMIME(mhtml) Parts(): %#v", doc.Parts())
+	}
+
+	doc = FromMIME(strings.NewReader(testEML()))
+	if text, err := doc.GetDataSingle("p"); err != nil || text != "Привет" {
+		t.Errorf("FromMIME(eml) text: %q, %v", text, err)
+	}
+	src, _ = doc.GetDataSingle("img:attr(src)")
+	if part, ok := doc.Part(src); !ok || part.ContentID != "logo@example" || string(part.Body) != "GIF89a" {
+		t.Errorf("FromMIME(eml) Part(%q): %#v, %v", src, part, ok)
+	}
+	if _, ok := doc.Part("cid:unknown"); ok {
+		t.Errorf("FromMIME(eml) Part(cid:unknown): not found expected")
+	}
+	if len(doc.Parts()) != 2 {
+		t.Errorf("FromMIME(eml) Parts(): %#v", doc.Parts())
+	}
+
+	for _, content := range []string{"not mime", "Content-Type: text/plain\r\n\r\ntext"} {
+		if doc := FromMIME(strings.NewReader(content)); doc.Err == nil {
+			t.Errorf("FromMIME(%q): error expected", content)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package html2data
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress - error for requests to loopback, private and link-local addresses (with URLCfg.DenyPrivateNetworks)
+var ErrPrivateAddress = errors.New("address is not allowed (loopback, private or link-local network)")
+
+// privateNetworks - not public networks which are not covered by methods of net.IP
+var privateNetworks = parseCIDRs("0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15", "240.0.0.0/4")
+
+// denyPrivateTransport - transport which refuses to connect to not public addresses,
+// address is checked after resolving, so redirects and DNS names are checked too.
+// Proxy is not used: connection to proxy hides address of target.
+var denyPrivateTransport = &http.Transport{
+	DialContext: (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   denyPrivateControl,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: time.Second,
+}
+
+// newHTTPClient - http client for URLCfg
+func newHTTPClient(config URLCfg, jar http.CookieJar) *http.Client {
+	client := &http.Client{
+		Jar:     jar,
+		Timeout: time.Duration(config.TimeOut) * time.Second,
+	}
+	if config.DenyPrivateNetworks {
+		client.Transport = denyPrivateTransport
+	}
+
+	return client
+}
+
+// denyPrivateControl - check resolved address before connect
+func denyPrivateControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || isPrivateIP(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+	}
+
+	return nil
+}
+
+// isPrivateIP - check that address is not public: loopback, private, link-local, multicast, reserved
+func isPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseCIDRs - parse list of networks
+func parseCIDRs(cidrs ...string) (result []*net.IPNet) {
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		result = append(result, network)
+	}
+
+	return result
+}
@@ -0,0 +1,65 @@
+package html2data
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_isPrivateIP(t *testing.T) {
+	testData := []struct {
+		ip       string
+		expected bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"fe80::1", true},
+		{"fd00::1", true},
+		{"8.8.8.8", false},
+		{"93.184.216.34", false},
+		{"2606:4700::1111", false},
+	}
+
+	for i, item := range testData {
+		if real := isPrivateIP(net.ParseIP(item.ip)); real != item.expected {
+			t.Errorf("%d. isPrivateIP(%s): expected: %v, real: %v", i, item.ip, item.expected, real)
+		}
+	}
+}
+
+func Test_FromURLDenyPrivateNetworks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<title>Local</title>"))
+	}))
+	defer ts.Close()
+
+	if doc := FromURL(ts.URL, URLCfg{DenyPrivateNetworks: true}); !errors.Is(doc.Err, ErrPrivateAddress) {
+		t.Errorf("FromURL() with DenyPrivateNetworks: expected ErrPrivateAddress, got: %v", doc.Err)
+	}
+	if doc := FromURL(ts.URL); doc.Err != nil {
+		t.Errorf("FromURL() without DenyPrivateNetworks: %s", doc.Err)
+	}
+}
+
+func Test_FromURLMaxBodySize(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<title>Local</title>"))
+	}))
+	defer ts.Close()
+
+	if doc := FromURL(ts.URL, URLCfg{MaxBodySize: 10}); !errors.Is(doc.Err, ErrBodyTooLarge) {
+		t.Errorf("FromURL() with small MaxBodySize: expected ErrBodyTooLarge, got: %v", doc.Err)
+	}
+	if doc := FromURL(ts.URL, URLCfg{MaxBodySize: 20}); doc.Err != nil {
+		t.Errorf("FromURL() with MaxBodySize equal to size of body: %s", doc.Err)
+	}
+}
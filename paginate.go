@@ -0,0 +1,104 @@
+package html2data
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrStopPaginate - return it from extract function for stop Paginate() without error
+var ErrStopPaginate = errors.New("stop paginate")
+
+// PaginateCfg - config for Paginate()
+type PaginateCfg struct {
+	URLCfg   URLCfg        // config for load pages
+	MaxPages int           // max count of pages, 0 - without limit
+	Delay    time.Duration // delay between pages
+}
+
+// Paginate - load pages from startURL following next page link found by nextSelector
+// (href attribute if pseudo-selector is not specified) and call extract for each page,
+// visited URLs are skipped, cookies are shared between pages
+//
+//	err := html2data.Paginate("http://example.com/list", "a.next:attr(href)", func(doc html2data.Doc) error {
+//		texts, err := doc.GetData(map[string]string{"items": "li.item"})
+//		...
+//	}, html2data.PaginateCfg{MaxPages: 10})
+func Paginate(startURL string, nextSelector string, extract func(Doc) error, configs ...PaginateCfg) error {
+	var config PaginateCfg
+	switch {
+	case len(configs) == 1:
+		config = configs[0]
+	case len(configs) > 1:
+		panic("Paginate(): only one config argument allowed")
+	}
+
+	selector := parseSelector(nextSelector)
+	if selector.attrName == "" && !selector.getHTML && !selector.getOuterHTML && !selector.getCleanHTML {
+		nextSelector += ":attr(href)"
+	}
+
+	var jar http.CookieJar
+	visited := map[string]bool{}
+	nextURL := startURL
+	for page := 1; nextURL != ""; page++ {
+		request, err := http.NewRequest("GET", nextURL, nil)
+		if err != nil {
+			return err
+		}
+		visited[request.URL.String()] = true
+
+		doc := fromRequest(request, config.URLCfg, jar)
+		if doc.Err != nil {
+			return fmt.Errorf("load page %s: %s", nextURL, doc.Err)
+		}
+		jar = doc.jar
+		visited[doc.URL] = true
+
+		if err := extract(doc); err != nil {
+			if errors.Is(err, ErrStopPaginate) {
+				return nil
+			}
+			return err
+		}
+
+		if config.MaxPages > 0 && page >= config.MaxPages {
+			break
+		}
+
+		if nextURL, err = doc.nextPageURL(nextSelector, visited); err != nil {
+			return err
+		}
+		if nextURL != "" && config.Delay > 0 {
+			time.Sleep(config.Delay)
+		}
+	}
+
+	return nil
+}
+
+// nextPageURL - get absolute URL of next page, or "" if not found or already visited
+func (doc Doc) nextPageURL(nextSelector string, visited map[string]bool) (string, error) {
+	next, err := doc.GetDataSingle(nextSelector)
+	if err != nil {
+		return "", err
+	}
+
+	base, err := doc.baseURL()
+	if err != nil {
+		return "", err
+	}
+
+	nextURL, ok := resolveURL(base, next)
+	if !ok {
+		return "", nil
+	}
+	nextURL.Fragment = ""
+
+	if nextURL.Scheme != "http" && nextURL.Scheme != "https" || visited[nextURL.String()] {
+		return "", nil
+	}
+
+	return nextURL.String(), nil
+}
@@ -0,0 +1,68 @@
+package html2data
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func Test_Paginate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/list/1":
+			_, _ = fmt.Fprint(w, `<li>one</li><li>two</li><a class="next" href="2">next</a>`)
+		case "/list/2":
+			_, _ = fmt.Fprint(w, `<li>three</li><a class="next" href="/list/3#top">next</a>`)
+		case "/list/3":
+			_, _ = fmt.Fprint(w, `<li>four</li><a class="next" href="/list/1">next</a>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	items := []string{}
+	extract := func(doc Doc) error {
+		texts, err := doc.GetData(map[string]string{"items": "li"})
+		items = append(items, texts["items"]...)
+		return err
+	}
+
+	if err := Paginate(ts.URL+"/list/1", "a.next", extract); err != nil {
+		t.Errorf("Paginate() got error: %s", err)
+	}
+	if expected := []string{"one", "two", "three", "four"}; !reflect.DeepEqual(expected, items) {
+		t.Errorf("Paginate()\nexpected: %#v\nreal    : %#v", expected, items)
+	}
+
+	items = []string{}
+	if err := Paginate(ts.URL+"/list/1", "a.next:attr(href)", extract, PaginateCfg{MaxPages: 2}); err != nil {
+		t.Errorf("Paginate() with MaxPages got error: %s", err)
+	}
+	if expected := []string{"one", "two", "three"}; !reflect.DeepEqual(expected, items) {
+		t.Errorf("Paginate() with MaxPages\nexpected: %#v\nreal    : %#v", expected, items)
+	}
+
+	pages := 0
+	err := Paginate(ts.URL+"/list/1", "a.next", func(Doc) error {
+		pages++
+		return ErrStopPaginate
+	})
+	if err != nil || pages != 1 {
+		t.Errorf("Paginate() with ErrStopPaginate got: %d pages, %v", pages, err)
+	}
+
+	errExtract := errors.New("extract error")
+	if err := Paginate(ts.URL+"/list/1", "a.next", func(Doc) error { return errExtract }); !errors.Is(err, errExtract) {
+		t.Errorf("Paginate() with extract error got: %v", err)
+	}
+
+	if err := Paginate("fake://url", "a.next", extract); err == nil {
+		t.Errorf("Paginate() with invalid URL without error")
+	}
+
+	assertPanic(t, func() { _ = Paginate(ts.URL, "a", extract, PaginateCfg{}, PaginateCfg{}) }, "Paginate() with 2 config arguments")
+}
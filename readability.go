@@ -0,0 +1,265 @@
+package html2data
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Article - main content of page, result of MainContent()
+type Article struct {
+	Title     string     `json:"title"`
+	Byline    string     `json:"byline"`
+	Published *time.Time `json:"published,omitempty"` // nil if not found
+	Text      string     `json:"text"`
+	HTML      string     `json:"html"` // sanitized like :cleanhtml
+}
+
+var (
+	unlikelyCandidateRe = regexp.MustCompile(`(?i)banner|breadcrumb|combx|comment|community|cookie|disqus|extra|foot|header|menu|modal|related|remark|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|ad-break|advert|agegate|pagination|pager|popup|promo|subscribe`)
+	maybeCandidateRe    = regexp.MustCompile(`(?i)and|article|body|column|main|shadow|content`)
+	positiveClassRe     = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story`)
+	negativeClassRe     = regexp.MustCompile(`(?i)hidden|banner|combx|comment|com-|contact|foot|footer|footnote|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+	spacesRe            = regexp.MustCompile(`[ \t\r\n\x{00a0}]+`)
+)
+
+// removedForContent - elements which never contain main content
+const removedForContent = "script, style, noscript, template, iframe, object, embed, form, nav, aside, header, footer, button, select, input, textarea, svg"
+
+// scoredParagraphs - elements with text which are scored for select main content
+const scoredParagraphs = "p, pre, td, blockquote, section > div, article > div"
+
+// MainContent - extract main content (article body) of page without site-specific selectors,
+// by readability-like scoring of text blocks
+//
+//	article, err := doc.MainContent()
+func (doc Doc) MainContent() (article Article, err error) {
+	if doc.Err != nil {
+		return article, fmt.Errorf("parse document error: %s", doc.Err)
+	}
+	defer func() {
+		if errRecoverRaw := recover(); errRecoverRaw != nil {
+			article, err = Article{}, fmt.Errorf("%s", errRecoverRaw)
+		}
+	}()
+
+	article.Title = contentTitle(doc.doc)
+	article.Byline = firstNonEmpty(doc.doc, []string{
+		`meta[name="author"]:attr(content)`,
+		`meta[property="article:author"]:attr(content)`,
+		`[itemprop="author"] [itemprop="name"]`,
+		`[itemprop="author"]`,
+		`[rel="author"]`,
+		`.byline`,
+		`.author`,
+	})
+	published := firstNonEmpty(doc.doc, []string{
+		`meta[property="article:published_time"]:attr(content)`,
+		`meta[itemprop="datePublished"]:attr(content)`,
+		`[itemprop="datePublished"]:attr(datetime)`,
+		`meta[name="date"]:attr(content)`,
+		`time[datetime]:attr(datetime)`,
+	})
+	if date, ok := parseDate(published, "", nil); ok {
+		article.Published = &date
+	}
+
+	body, err := cloneForContent(doc.doc)
+	if err != nil {
+		return article, err
+	}
+
+	topCandidate := bestContentCandidate(body)
+	if topCandidate == nil {
+		return article, fmt.Errorf("main content not found")
+	}
+
+	article.Text = contentText(topCandidate)
+	article.HTML, err = cleanHTML(topCandidate, nil)
+
+	return article, err
+}
+
+// contentTitle - get title of article
+func contentTitle(doc docOrSelection) string {
+	if title := firstNonEmpty(doc, []string{`meta[property="og:title"]:attr(content)`, `meta[name="twitter:title"]:attr(content)`}); title != "" {
+		return title
+	}
+
+	title := normalizeSpaces(doc.Find("title").First().Text())
+	if headers := doc.Find("h1"); headers.Length() == 1 {
+		header := normalizeSpaces(headers.Text())
+		if title == "" || strings.Contains(title, header) {
+			return header
+		}
+	}
+
+	return title
+}
+
+// firstNonEmpty - get first non empty value by list of selectors
+func firstNonEmpty(doc docOrSelection, selectors []string) string {
+	for _, selectorRaw := range selectors {
+		selector := parseSelector(selectorRaw)
+		selection := doc.Find(selector.selector).First()
+		value := selection.Text()
+		if selector.attrName != "" {
+			value = selection.AttrOr(selector.attrName, "")
+		}
+
+		if value = normalizeSpaces(value); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// cloneForContent - copy of document body without elements which never contain main content
+func cloneForContent(doc docOrSelection) (*goquery.Selection, error) {
+	buf := bytes.Buffer{}
+	for _, node := range doc.Find("body").Nodes {
+		if err := html.Render(&buf, node); err != nil {
+			return nil, err
+		}
+	}
+
+	cloneDoc, err := goquery.NewDocumentFromReader(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	body := cloneDoc.Find("body")
+	body.Find(removedForContent).Remove()
+	body.Find("*").Each(func(_ int, selection *goquery.Selection) {
+		classAndID := selection.AttrOr("class", "") + " " + selection.AttrOr("id", "")
+		if unlikelyCandidateRe.MatchString(classAndID) && !maybeCandidateRe.MatchString(classAndID) && !selection.Is("body, article, main") {
+			selection.Remove()
+		}
+	})
+
+	return body, nil
+}
+
+// bestContentCandidate - score text blocks and get element with max score
+func bestContentCandidate(body *goquery.Selection) *goquery.Selection {
+	scores := map[*html.Node]float64{}
+	candidates := []*goquery.Selection{}
+
+	addScore := func(selection *goquery.Selection, score float64) {
+		if selection.Length() == 0 || selection.Is("html") {
+			return
+		}
+		node := selection.Get(0)
+		if _, ok := scores[node]; !ok {
+			scores[node] = initialScore(selection)
+			candidates = append(candidates, selection)
+		}
+		scores[node] += score
+	}
+
+	body.Find(scoredParagraphs).Each(func(_ int, paragraph *goquery.Selection) {
+		text := normalizeSpaces(paragraph.Text())
+		if len(text) < 25 {
+			return
+		}
+
+		score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text)/100), 3)
+		addScore(paragraph.Parent(), score)
+		addScore(paragraph.Parent().Parent(), score/2)
+	})
+
+	var (
+		best      *goquery.Selection
+		bestScore float64
+	)
+	for _, candidate := range candidates {
+		score := scores[candidate.Get(0)] * (1 - linkDensity(candidate))
+		if best == nil || score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+
+	if best == nil && strings.TrimSpace(body.Text()) != "" {
+		return body
+	}
+
+	return best
+}
+
+// initialScore - score of element by tag name and class/id
+func initialScore(selection *goquery.Selection) (score float64) {
+	switch goquery.NodeName(selection) {
+	case "article", "main":
+		score = 10
+	case "div":
+		score = 5
+	case "pre", "td", "blockquote":
+		score = 3
+	case "address", "ol", "ul", "dl", "dd", "dt", "li", "form":
+		score = -3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		score = -5
+	}
+
+	for _, attr := range []string{"class", "id"} {
+		value := selection.AttrOr(attr, "")
+		if value == "" {
+			continue
+		}
+		if negativeClassRe.MatchString(value) {
+			score -= 25
+		}
+		if positiveClassRe.MatchString(value) {
+			score += 25
+		}
+	}
+
+	return score
+}
+
+// linkDensity - part of text inside links
+func linkDensity(selection *goquery.Selection) float64 {
+	textLength := len(normalizeSpaces(selection.Text()))
+	if textLength == 0 {
+		return 0
+	}
+
+	linksLength := 0
+	selection.Find("a").Each(func(_ int, link *goquery.Selection) {
+		linksLength += len(normalizeSpaces(link.Text()))
+	})
+
+	return float64(linksLength) / float64(textLength)
+}
+
+// contentText - text of content, separated by paragraphs
+func contentText(selection *goquery.Selection) string {
+	paragraphs := []string{}
+	selection.Find("h1, h2, h3, h4, h5, h6, p, pre, li, blockquote, dt, dd, figcaption").Each(func(_ int, paragraph *goquery.Selection) {
+		// skip paragraphs inside other paragraphs, they are already in text
+		if paragraph.ParentsFiltered("p, pre, li, blockquote, dd").Length() > 0 {
+			return
+		}
+		if text := normalizeSpaces(paragraph.Text()); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	})
+
+	if len(paragraphs) == 0 {
+		return normalizeSpaces(selection.Text())
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// normalizeSpaces - replace all spaces sequences with one space
+func normalizeSpaces(text string) string {
+	return strings.TrimSpace(spacesRe.ReplaceAllString(text, " "))
+}
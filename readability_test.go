@@ -0,0 +1,68 @@
+package html2data
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_MainContent(t *testing.T) {
+	page := `<html><head><title>Big news | Site</title><meta name="author" content="John Doe">
+<meta property="article:published_time" content="2024-05-01T10:00:00Z"></head>
+<body>
+<nav><a href="/">Home</a> <a href="/news">News</a></nav>
+<div class="sidebar"><p>Subscribe to our newsletter, get news, offers, and more, every day of the week.</p></div>
+<div class="post-content">
+	<h1>Big news</h1>
+	<p>First paragraph of the article, with some commas, and long enough text to be scored.</p>
+	<script>alert(1)</script>
+	<p onclick="x()">Second paragraph of the article, also long enough, with <b>bold</b> text.</p>
+</div>
+<div class="comments"><p>Comment text, which is long enough to be scored as a paragraph too.</p></div>
+<footer><p>Copyright footer text which is long enough to be a paragraph, but in footer.</p></footer>
+</body></html>`
+
+	article, err := FromReader(strings.NewReader(page)).MainContent()
+	if err != nil {
+		t.Fatalf("MainContent() got error: %s", err)
+	}
+
+	if article.Title != "Big news" {
+		t.Errorf("MainContent() title: %q", article.Title)
+	}
+	if article.Byline != "John Doe" {
+		t.Errorf("MainContent() byline: %q", article.Byline)
+	}
+	if article.Published == nil || !article.Published.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("MainContent() published: %v", article.Published)
+	}
+
+	expectedText := "Big news\n\n" +
+		"First paragraph of the article, with some commas, and long enough text to be scored.\n\n" +
+		"Second paragraph of the article, also long enough, with bold text."
+	if article.Text != expectedText {
+		t.Errorf("MainContent() text:\nexpected: %q\nreal    : %q", expectedText, article.Text)
+	}
+
+	if !strings.HasPrefix(article.HTML, "<div>") || strings.Contains(article.HTML, "script") || strings.Contains(article.HTML, "onclick") {
+		t.Errorf("MainContent() html: %q", article.HTML)
+	}
+
+	// without date "published" is omitted in JSON
+	article, err = FromReader(strings.NewReader(strings.ReplaceAll(page, "2024-05-01T10:00:00Z", ""))).MainContent()
+	if err != nil || article.Published != nil {
+		t.Errorf("MainContent() without date got: %v, %v", article.Published, err)
+	}
+	if articleJSON, err := json.Marshal(article); err != nil || strings.Contains(string(articleJSON), "published") {
+		t.Errorf("MainContent() without date in JSON got: %s, %v", articleJSON, err)
+	}
+
+	if _, err := FromReader(strings.NewReader("")).MainContent(); err == nil {
+		t.Errorf("MainContent() on empty document without error")
+	}
+
+	if _, err := FromFile("/dont exists file").MainContent(); err == nil {
+		t.Errorf("MainContent() on document with error without error")
+	}
+}
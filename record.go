@@ -0,0 +1,29 @@
+package html2data
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// recordPage - save response to fixture directory (URLCfg.RecordDir) for replay
+func recordPage(recordDir string, request *http.Request, page fetchedPage) error {
+	return writeCacheEntry(cacheFileName(recordDir, request), cacheEntry{
+		URL:        request.URL.String(),
+		FinalURL:   page.URL,
+		StatusCode: page.StatusCode,
+		Header:     page.Header,
+		Body:       page.Body,
+		StoredAt:   time.Now(),
+	})
+}
+
+// replayPage - get recorded response by method and URL from fixture directory (URLCfg.ReplayDir)
+func replayPage(replayDir string, request *http.Request) (fetchedPage, error) {
+	entry, ok := readCacheEntry(cacheFileName(replayDir, request))
+	if !ok {
+		return fetchedPage{}, fmt.Errorf("replay: response for %s %s is not recorded", request.Method, request.URL)
+	}
+
+	return entry.page(), nil
+}
@@ -0,0 +1,37 @@
+package html2data
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_RecordReplay(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/page", http.StatusFound)
+			return
+		}
+		w.Header().Set("X-Test", "value")
+		_, _ = fmt.Fprintf(w, "<div>%s %s</div>", r.Method, r.URL.Path)
+	}))
+
+	fixtures := t.TempDir()
+	for _, path := range []string{"/page", "/redirect"} {
+		if doc := FromURL(ts.URL+path, URLCfg{RecordDir: fixtures}); doc.Err != nil {
+			t.Errorf("record %s got error: %s", path, doc.Err)
+		}
+	}
+	ts.Close()
+
+	doc := FromURL(ts.URL+"/redirect", URLCfg{ReplayDir: fixtures})
+	div, err := doc.GetDataSingle("div")
+	if err != nil || div != "GET /page" || doc.URL != ts.URL+"/page" {
+		t.Errorf("replay got: %q, URL: %s, error: %v", div, doc.URL, err)
+	}
+
+	if doc := FromURL(ts.URL+"/not-recorded", URLCfg{ReplayDir: fixtures}); doc.Err == nil {
+		t.Errorf("replay of not recorded URL without error")
+	}
+}
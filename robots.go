@@ -0,0 +1,288 @@
+package html2data
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDisallowedByRobots - error for URLs disallowed by robots.txt (with URLCfg.RespectRobots)
+var ErrDisallowedByRobots = errors.New("disallowed by robots.txt")
+
+// robotsMaxSize - max size of robots.txt, the rest is ignored
+const robotsMaxSize = 500 * 1024
+
+// robotsMaxCrawlDelay - max honoured Crawl-delay, larger values are capped
+const robotsMaxCrawlDelay = 30 * time.Second
+
+// robotsRetryTTL - time after which robots.txt is loaded again after network error or 5xx,
+// parsed robots.txt and 4xx are cached for the life of the process
+var robotsRetryTTL = time.Minute
+
+// robotsRule - Allow or Disallow rule
+type robotsRule struct {
+	allow   bool
+	length  int // length of pattern for precedence
+	pattern *regexp.Regexp
+}
+
+// robotsGroup - rules for group of user-agents
+type robotsGroup struct {
+	agents     []string
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// robotsRules - parsed robots.txt
+type robotsRules struct {
+	groups      []robotsGroup
+	disallowAll bool // robots.txt is unreachable
+}
+
+// robotsHost - robots.txt rules of host and time of last request for Crawl-delay
+type robotsHost struct {
+	sync.Mutex
+	load        sync.Mutex
+	loaded      bool
+	expires     time.Time // time for retry of temporary error, zero for robots.txt loaded without errors
+	rules       robotsRules
+	nextRequest time.Time // time of next request by Crawl-delay
+}
+
+// robotsCache - robots.txt rules by scheme://host
+var robotsCache = struct {
+	sync.Mutex
+	hosts map[string]*robotsHost
+}{hosts: map[string]*robotsHost{}}
+
+// checkRobots - check that URL is allowed by robots.txt for user-agent and wait Crawl-delay,
+// wait is cancelled with context of request
+func checkRobots(ctx context.Context, requestURL *url.URL, config URLCfg) error {
+	if requestURL.Scheme != "http" && requestURL.Scheme != "https" || requestURL.Path == "/robots.txt" {
+		return nil
+	}
+
+	host, rules := getRobotsHost(requestURL, config)
+	ua := config.UA
+	if ua == "" {
+		ua = "Go-http-client"
+	}
+
+	group := rules.group(ua)
+	if rules.disallowAll || !group.isAllowed(requestURL.RequestURI()) {
+		return fmt.Errorf("%w: %s", ErrDisallowedByRobots, requestURL)
+	}
+
+	delay := group.crawlDelay
+	if delay > robotsMaxCrawlDelay {
+		delay = robotsMaxCrawlDelay
+	}
+
+	// reserve time of request under lock, and wait without lock
+	host.Lock()
+	start := time.Now()
+	if host.nextRequest.After(start) {
+		start = host.nextRequest
+	}
+	host.nextRequest = start.Add(delay)
+	host.Unlock()
+
+	wait := time.Until(start)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// getRobotsHost - get cached or load robots.txt of host, temporary errors are retried after robotsRetryTTL
+func getRobotsHost(requestURL *url.URL, config URLCfg) (*robotsHost, robotsRules) {
+	key := requestURL.Scheme + "://" + requestURL.Host
+
+	robotsCache.Lock()
+	host, ok := robotsCache.hosts[key]
+	if !ok {
+		host = &robotsHost{}
+		robotsCache.hosts[key] = host
+	}
+	robotsCache.Unlock()
+
+	host.load.Lock()
+	defer host.load.Unlock()
+	if !host.loaded || !host.expires.IsZero() && time.Now().After(host.expires) {
+		rules, temporary := loadRobots(key+"/robots.txt", config)
+		host.rules, host.loaded, host.expires = rules, true, time.Time{}
+		if temporary {
+			host.expires = time.Now().Add(robotsRetryTTL)
+		}
+	}
+
+	return host, host.rules
+}
+
+// loadRobots - load robots.txt, 4xx - allow all, 5xx, network or read error - disallow all (temporary)
+func loadRobots(robotsURL string, config URLCfg) (rules robotsRules, temporary bool) {
+	client := newHTTPClient(config, nil)
+	request, err := http.NewRequest("GET", robotsURL, nil)
+	if err != nil {
+		return robotsRules{disallowAll: true}, false
+	}
+	if config.UA != "" {
+		request.Header.Set("User-Agent", config.UA)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return robotsRules{disallowAll: true}, true
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, robotsMaxSize))
+	if errClose := response.Body.Close(); err == nil {
+		err = errClose
+	}
+
+	switch {
+	case response.StatusCode >= 500:
+		return robotsRules{disallowAll: true}, true
+	case response.StatusCode >= 400:
+		return robotsRules{}, false
+	case err != nil:
+		return robotsRules{disallowAll: true}, true
+	}
+
+	rules, err = parseRobots(bytes.NewReader(body))
+	if err != nil {
+		// rules after unreadable line are unknown
+		return robotsRules{disallowAll: true}, true
+	}
+
+	return rules, false
+}
+
+// parseRobots - parse robots.txt, returns error if it can't be read to the end (line longer than bufio.MaxScanTokenSize)
+func parseRobots(reader io.Reader) (result robotsRules, err error) {
+	var (
+		current     *robotsGroup
+		inAgentList bool
+	)
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key, value := strings.ToLower(strings.TrimSpace(parts[0])), strings.TrimSpace(parts[1])
+
+		if key == "user-agent" {
+			if !inAgentList {
+				result.groups = append(result.groups, robotsGroup{})
+				current = &result.groups[len(result.groups)-1]
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			inAgentList = true
+			continue
+		}
+
+		inAgentList = false
+		if current == nil {
+			continue
+		}
+
+		switch key {
+		case "allow", "disallow":
+			if value == "" {
+				continue
+			}
+			current.rules = append(current.rules, robotsRule{
+				allow:   key == "allow",
+				length:  len(value),
+				pattern: robotsPattern(value),
+			})
+		case "crawl-delay":
+			if delay, err := strconv.ParseFloat(value, 64); err == nil && delay > 0 {
+				current.crawlDelay = time.Duration(delay * float64(time.Second))
+			}
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+// robotsPattern - convert robots.txt path pattern with "*" and "$" to regexp
+func robotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	reString := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	if anchored {
+		reString += "$"
+	}
+
+	return regexp.MustCompile(reString)
+}
+
+// group - get rules for user-agent: all groups with the most specific matched agent or "*"
+func (rules robotsRules) group(ua string) (result robotsGroup) {
+	ua = strings.ToLower(ua)
+	bestAgent := ""
+	for _, group := range rules.groups {
+		for _, agent := range group.agents {
+			if agent != "*" && strings.Contains(ua, agent) && len(agent) > len(bestAgent) {
+				bestAgent = agent
+			}
+		}
+	}
+	if bestAgent == "" {
+		bestAgent = "*"
+	}
+
+	for _, group := range rules.groups {
+		for _, agent := range group.agents {
+			if agent == bestAgent {
+				result.rules = append(result.rules, group.rules...)
+				if group.crawlDelay > result.crawlDelay {
+					result.crawlDelay = group.crawlDelay
+				}
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// isAllowed - check path by the longest matched rule, Allow wins for rules with equal length
+func (group robotsGroup) isAllowed(path string) bool {
+	allowed, matchedLength := true, -1
+	for _, rule := range group.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > matchedLength || (rule.length == matchedLength && rule.allow) {
+			allowed, matchedLength = rule.allow, rule.length
+		}
+	}
+
+	return allowed
+}
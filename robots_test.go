@@ -0,0 +1,204 @@
+package html2data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseRobots(t *testing.T) {
+	rules, err := parseRobots(strings.NewReader(`# comment
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+Disallow: /search?q=
+
+User-agent: BadBot
+User-agent: OtherBot
+Disallow: /
+
+User-agent: goodbot # comment
+Allow: /
+Crawl-delay: 0.5
+`))
+	if err != nil {
+		t.Fatalf("parseRobots() got error: %s", err)
+	}
+
+	testData := []struct {
+		ua      string
+		path    string
+		allowed bool
+	}{
+		{"Go-http-client", "/", true},
+		{"Go-http-client", "/private", false},
+		{"Go-http-client", "/private/page", false},
+		{"Go-http-client", "/private/public/page", true},
+		{"Go-http-client", "/file.pdf", false},
+		{"Go-http-client", "/file.pdf?x=1", true},
+		{"Go-http-client", "/search?q=test", false},
+		{"BadBot/1.0", "/page", false},
+		{"Mozilla/5.0 (compatible; OtherBot/2.1)", "/page", false},
+		{"GoodBot/1.0", "/private", true},
+	}
+
+	for i, item := range testData {
+		if allowed := rules.group(item.ua).isAllowed(item.path); allowed != item.allowed {
+			t.Errorf("%d. %s %s: expected: %v, real: %v", i, item.ua, item.path, item.allowed, allowed)
+		}
+	}
+
+	if delay := rules.group("GoodBot/1.0").crawlDelay; delay != 500*time.Millisecond {
+		t.Errorf("Crawl-delay expected 0.5s, real: %s", delay)
+	}
+
+	// line longer than scanner buffer
+	if _, err := parseRobots(strings.NewReader("User-agent: *\n# " + strings.Repeat("x", 70*1024) + "\nDisallow: /\n")); err == nil {
+		t.Errorf("parseRobots() with too long line: expected error")
+	}
+}
+
+func Test_RespectRobots(t *testing.T) {
+	robotsRequests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			robotsRequests++
+			_, _ = fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+		case "/redirect":
+			http.Redirect(w, r, "/private/page", http.StatusFound)
+		default:
+			_, _ = fmt.Fprintf(w, "<div>%s</div>", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	if doc := FromURL(ts.URL+"/private/page", URLCfg{RespectRobots: true}); !errors.Is(doc.Err, ErrDisallowedByRobots) {
+		t.Errorf("RespectRobots: expected ErrDisallowedByRobots, got: %v", doc.Err)
+	}
+	if doc := FromURL(ts.URL+"/redirect", URLCfg{RespectRobots: true}); !errors.Is(doc.Err, ErrDisallowedByRobots) {
+		t.Errorf("RespectRobots with redirect: expected ErrDisallowedByRobots, got: %v", doc.Err)
+	}
+	if div, err := FromURL(ts.URL+"/page", URLCfg{RespectRobots: true}).GetDataSingle("div"); err != nil || div != "/page" {
+		t.Errorf("RespectRobots: allowed page got: %q, %v", div, err)
+	}
+	if robotsRequests != 1 {
+		t.Errorf("RespectRobots: robots.txt must be cached, requests: %d", robotsRequests)
+	}
+	if doc := FromURL(ts.URL + "/private/page"); doc.Err != nil {
+		t.Errorf("without RespectRobots got error: %s", doc.Err)
+	}
+
+	// robots.txt not found - allow all, server error - disallow all
+	for status, allowed := range map[int]bool{http.StatusNotFound: true, http.StatusServiceUnavailable: false} {
+		status := status
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/robots.txt" {
+				w.WriteHeader(status)
+				return
+			}
+			_, _ = fmt.Fprint(w, "<div>data</div>")
+		}))
+		if doc := FromURL(ts.URL+"/page", URLCfg{RespectRobots: true}); (doc.Err == nil) != allowed {
+			t.Errorf("RespectRobots with robots.txt status %d got: %v", status, doc.Err)
+		}
+		ts.Close()
+	}
+
+	// unreadable robots.txt - disallow all
+	tsLongLine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			_, _ = fmt.Fprint(w, "User-agent: *\nAllow: /public\n# "+strings.Repeat("x", 70*1024)+"\nDisallow: /\n")
+			return
+		}
+		_, _ = fmt.Fprint(w, "<div>data</div>")
+	}))
+	defer tsLongLine.Close()
+	if doc := FromURL(tsLongLine.URL+"/page", URLCfg{RespectRobots: true}); !errors.Is(doc.Err, ErrDisallowedByRobots) {
+		t.Errorf("RespectRobots with too long line in robots.txt: expected ErrDisallowedByRobots, got: %v", doc.Err)
+	}
+}
+
+func Test_RespectRobotsRetry(t *testing.T) {
+	defer func(ttl time.Duration) { robotsRetryTTL = ttl }(robotsRetryTTL)
+	robotsRetryTTL = 50 * time.Millisecond
+
+	robotsRequests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			robotsRequests++
+			if robotsRequests == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+			return
+		}
+		_, _ = fmt.Fprint(w, "<div>data</div>")
+	}))
+	defer ts.Close()
+
+	// temporary error of robots.txt disallows all until retry
+	for _, item := range []struct {
+		sleep   time.Duration
+		path    string
+		allowed bool
+	}{
+		{0, "/page", false},
+		{0, "/page", false},
+		{100 * time.Millisecond, "/page", true},
+		{100 * time.Millisecond, "/private", false},
+	} {
+		time.Sleep(item.sleep)
+		if doc := FromURL(ts.URL+item.path, URLCfg{RespectRobots: true}); (doc.Err == nil) != item.allowed {
+			t.Errorf("RespectRobots after %s for %s got: %v", item.sleep, item.path, doc.Err)
+		}
+	}
+	if robotsRequests != 2 {
+		t.Errorf("RespectRobots: robots.txt must be loaded again only after error, requests: %d", robotsRequests)
+	}
+}
+
+func Test_RespectRobotsCrawlDelay(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			_, _ = fmt.Fprint(w, "User-agent: *\nCrawl-delay: 3600\n")
+			return
+		}
+		_, _ = fmt.Fprint(w, "<div>data</div>")
+	}))
+	defer ts.Close()
+
+	if doc := FromURL(ts.URL+"/1", URLCfg{RespectRobots: true}); doc.Err != nil {
+		t.Fatalf("RespectRobots with Crawl-delay, first request: %s", doc.Err)
+	}
+
+	// the second request waits capped Crawl-delay, and is cancelled with context
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if doc := FromURLContext(ctx, ts.URL+"/2", URLCfg{RespectRobots: true}); !errors.Is(doc.Err, context.DeadlineExceeded) {
+		t.Errorf("RespectRobots with Crawl-delay: expected context.DeadlineExceeded, got: %v", doc.Err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("RespectRobots with Crawl-delay: request is not cancelled, elapsed: %s", elapsed)
+	}
+
+	tsURL, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, _ := getRobotsHost(tsURL, URLCfg{})
+	host.Lock()
+	defer host.Unlock()
+	if wait := time.Until(host.nextRequest); wait > 2*robotsMaxCrawlDelay {
+		t.Errorf("Crawl-delay is not capped, next request after: %s", wait)
+	}
+}
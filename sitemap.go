@@ -0,0 +1,127 @@
+package html2data
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// SitemapURL - URL from sitemap.xml
+type SitemapURL struct {
+	Loc        string `xml:"loc" json:"loc"`
+	LastMod    string `xml:"lastmod" json:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq" json:"changefreq,omitempty"`
+	Priority   string `xml:"priority" json:"priority,omitempty"`
+}
+
+// sitemapXML - <urlset> or <sitemapindex>
+type sitemapXML struct {
+	URLs     []SitemapURL `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// ParseSitemap - parse sitemap (<urlset>), sitemap index (<sitemapindex>) or text sitemap (URL per line),
+// gzipped (and bzip2) sitemaps are detected automatically, returns page URLs and URLs of nested sitemaps
+func ParseSitemap(reader io.Reader) (urls []SitemapURL, sitemaps []string, err error) {
+	if reader, err = decompress(reader); err != nil {
+		return nil, nil, err
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content = bytes.TrimSpace(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf")))
+	if !bytes.HasPrefix(content, []byte("<")) {
+		// text sitemap
+		for _, line := range strings.Split(string(content), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				urls = append(urls, SitemapURL{Loc: line})
+			}
+		}
+		return urls, nil, nil
+	}
+
+	sitemap := sitemapXML{}
+	if err := xml.Unmarshal(content, &sitemap); err != nil {
+		return nil, nil, fmt.Errorf("parse sitemap: %s", err)
+	}
+
+	for _, item := range sitemap.URLs {
+		item.Loc = strings.TrimSpace(item.Loc)
+		if item.Loc != "" {
+			urls = append(urls, item)
+		}
+	}
+	for _, item := range sitemap.Sitemaps {
+		if loc := strings.TrimSpace(item.Loc); loc != "" {
+			sitemaps = append(sitemaps, loc)
+		}
+	}
+
+	return urls, sitemaps, nil
+}
+
+// ReadSitemap - get all page URLs from sitemap by URL or local file name,
+// nested sitemaps from sitemap index are loaded too
+//
+//	urls, err := html2data.ReadSitemap("https://example.com/sitemap.xml")
+func ReadSitemap(sitemapURL string, config ...URLCfg) (result []SitemapURL, err error) {
+	if len(config) > 1 {
+		panic("ReadSitemap(): only one config argument allowed")
+	}
+
+	visited := map[string]bool{}
+	queue := []string{sitemapURL}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		urls, sitemaps, err := loadSitemap(current, getURLConfig(config))
+		if err != nil {
+			return result, fmt.Errorf("sitemap %s: %s", current, err)
+		}
+
+		result = append(result, urls...)
+		queue = append(queue, sitemaps...)
+	}
+
+	return result, nil
+}
+
+// loadSitemap - load and parse one sitemap from URL or file
+func loadSitemap(sitemapURL string, config URLCfg) (urls []SitemapURL, sitemaps []string, err error) {
+	if !strings.HasPrefix(sitemapURL, "http://") && !strings.HasPrefix(sitemapURL, "https://") {
+		content, err := os.ReadFile(sitemapURL) // #nosec
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return ParseSitemap(bytes.NewReader(content))
+	}
+
+	request, err := http.NewRequest("GET", sitemapURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page, err := fetch(request, config, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if page.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("http status: %d", page.StatusCode)
+	}
+
+	return ParseSitemap(bytes.NewReader(page.Body))
+}
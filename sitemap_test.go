@@ -0,0 +1,70 @@
+package html2data
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_ParseSitemap(t *testing.T) {
+	urls, sitemaps, err := ParseSitemap(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+	<url><loc> http://example.com/1 </loc><lastmod>2024-05-01</lastmod><priority>0.8</priority></url>
+	<url><loc>http://example.com/2</loc></url>
+</urlset>`))
+	expected := []SitemapURL{{Loc: "http://example.com/1", LastMod: "2024-05-01", Priority: "0.8"}, {Loc: "http://example.com/2"}}
+	if err != nil || sitemaps != nil || !reflect.DeepEqual(expected, urls) {
+		t.Errorf("ParseSitemap() urlset got: %#v, %#v, %v", urls, sitemaps, err)
+	}
+
+	urls, sitemaps, err = ParseSitemap(strings.NewReader("http://example.com/1\n\nhttp://example.com/2\n"))
+	expected = []SitemapURL{{Loc: "http://example.com/1"}, {Loc: "http://example.com/2"}}
+	if err != nil || sitemaps != nil || !reflect.DeepEqual(expected, urls) {
+		t.Errorf("ParseSitemap() text got: %#v, %#v, %v", urls, sitemaps, err)
+	}
+
+	if _, _, err = ParseSitemap(strings.NewReader("<urlset><url>")); err == nil {
+		t.Errorf("ParseSitemap() invalid XML without error")
+	}
+}
+
+func Test_ReadSitemap(t *testing.T) {
+	gzipped := bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(&gzipped)
+	_, _ = fmt.Fprint(gzipWriter, `<urlset><url><loc>http://example.com/3</loc></url></urlset>`)
+	_ = gzipWriter.Close()
+
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			_, _ = fmt.Fprintf(w, `<sitemapindex><sitemap><loc>%[1]s/s1.xml</loc></sitemap><sitemap><loc>%[1]s/s2.xml.gz</loc></sitemap><sitemap><loc>%[1]s/sitemap.xml</loc></sitemap></sitemapindex>`, ts.URL)
+		case "/s1.xml":
+			_, _ = fmt.Fprint(w, `<urlset><url><loc>http://example.com/1</loc></url><url><loc>http://example.com/2</loc></url></urlset>`)
+		case "/s2.xml.gz":
+			w.Header().Set("Content-Type", "application/gzip")
+			_, _ = w.Write(gzipped.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	urls, err := ReadSitemap(ts.URL + "/sitemap.xml")
+	expected := []SitemapURL{{Loc: "http://example.com/1"}, {Loc: "http://example.com/2"}, {Loc: "http://example.com/3"}}
+	if err != nil || !reflect.DeepEqual(expected, urls) {
+		t.Errorf("ReadSitemap() got: %#v, %v", urls, err)
+	}
+
+	if _, err := ReadSitemap(ts.URL + "/not-found.xml"); err == nil {
+		t.Errorf("ReadSitemap() not found sitemap without error")
+	}
+	if _, err := ReadSitemap("/dont exists file"); err == nil {
+		t.Errorf("ReadSitemap() not exists file without error")
+	}
+}
@@ -0,0 +1,359 @@
+package html2data
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// SelectorSuggestion - CSS selector proposed by SuggestSelectors()
+type SelectorSuggestion struct {
+	Selector    string   `json:"selector"`    // CSS selector, with :attr(name) if example is found in attribute
+	Specificity int      `json:"specificity"` // CSS specificity: ids*100 + (classes, attributes)*10 + tags
+	Matches     int      `json:"matches"`     // count of elements found by selector: the example element and similar elements
+	Values      []string `json:"values"`      // values of found elements
+}
+
+const (
+	maxSuggestTargets     = 10 // max count of elements with example text for suggest selectors
+	maxSuggestSelectors   = 10 // max count of suggested selectors
+	maxSuggestAncestors   = 3  // max depth of ancestors with id or class for selector with context
+	suggestKindID         = 0  // kinds of selectors in order of preference
+	suggestKindAttr       = 1
+	suggestKindClass      = 2
+	suggestKindTag        = 3
+	suggestSelectorsLimit = 200 // max count of checked selectors
+)
+
+var (
+	// suggestNameRe - names which can be used in selector without escaping
+	suggestNameRe = regexp.MustCompile(`^-?[a-zA-Z_][\w-]*$`)
+	// generatedNameRe - generated names of CSS-in-JS and CSS modules: "css-1x2y3z", "sc-bdVaJa", "Title_title__3xYz"
+	generatedNameRe = regexp.MustCompile(`^(?:css|sc|jsx|emotion|styled|ember|react)-|__[a-zA-Z0-9]{5,}$|^[a-zA-Z]{1,3}[0-9][a-zA-Z0-9]{3,}$`)
+	// suggestAttrs - attributes with text which can be the example
+	suggestAttrs = []string{"title", "alt", "content", "value", "aria-label"}
+)
+
+// suggestCandidate - candidate selector for element
+type suggestCandidate struct {
+	selector    string
+	inner       string // selector of element itself for selector with context of ancestor
+	attr        string // attribute with example, for :attr()
+	kind        int
+	parts       int
+	specificity int
+}
+
+// SuggestSelectors - find elements with example text (or attribute value) and propose stable and minimal
+// CSS selectors for them: ids, data-attributes and microdata, classes without generated names, tags,
+// with context of ancestor if needed. Only selectors which find the example element and similar elements
+// (the same tag, classes, itemprop/name/property) are proposed, sorted by preference (id, attribute, class, tag),
+// by count of parts, by count of found elements (more general first) and by specificity.
+//
+//	suggestions, err := html2data.SuggestSelectors(doc, "Example Product Name")
+//	for _, suggestion := range suggestions {
+//		fmt.Println(suggestion.Selector, suggestion.Matches)
+//	}
+func SuggestSelectors(doc Doc, example string) ([]SelectorSuggestion, error) {
+	if doc.Err != nil {
+		return nil, doc.Err
+	}
+	example = strings.ToLower(normalizeSpaces(example))
+	if example == "" {
+		return nil, errors.New("example text is empty")
+	}
+
+	targets, attrs := suggestTargets(doc, example)
+	if len(targets) == 0 {
+		return nil, errors.New("example text is not found")
+	}
+
+	type scored struct {
+		candidate suggestCandidate
+		matches   int
+	}
+	checked := map[string]bool{}
+	results := []scored{}
+	for i, target := range targets {
+		for _, candidate := range doc.suggestCandidates(target, attrs[i]) {
+			if checked[candidate.selector] || len(checked) >= suggestSelectorsLimit {
+				continue
+			}
+			checked[candidate.selector] = true
+
+			if matches, ok := doc.suggestMatches(candidate.selector, target); ok {
+				results = append(results, scored{candidate: candidate, matches: matches})
+			}
+		}
+	}
+
+	// context of ancestor is needed only if it found fewer elements than selector of element itself
+	found := map[string]int{}
+	for _, result := range results {
+		if result.candidate.inner == "" {
+			found[result.candidate.selector] = result.matches
+		}
+	}
+	minimal := results[:0]
+	for _, result := range results {
+		if matches, ok := found[result.candidate.inner]; !ok || matches != result.matches {
+			minimal = append(minimal, result)
+		}
+	}
+	results = minimal
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		switch {
+		case a.candidate.kind != b.candidate.kind:
+			return a.candidate.kind < b.candidate.kind
+		case a.candidate.parts != b.candidate.parts:
+			return a.candidate.parts < b.candidate.parts
+		case a.matches != b.matches:
+			return a.matches > b.matches
+		default:
+			return a.candidate.specificity < b.candidate.specificity
+		}
+	})
+
+	suggestions := []SelectorSuggestion{}
+	for _, result := range results {
+		if len(suggestions) == maxSuggestSelectors {
+			break
+		}
+
+		selector := result.candidate.selector
+		if result.candidate.attr != "" {
+			selector += ":attr(" + result.candidate.attr + ")"
+		}
+		values, err := doc.GetData(map[string]string{"values": selector})
+		if err != nil {
+			return nil, err
+		}
+
+		suggestions = append(suggestions, SelectorSuggestion{
+			Selector:    selector,
+			Specificity: result.candidate.specificity,
+			Matches:     result.matches,
+			Values:      values["values"],
+		})
+	}
+
+	return suggestions, nil
+}
+
+// suggestTargets - the deepest elements with example in text, or elements with example in attribute
+func suggestTargets(doc Doc, example string) (targets []*html.Node, attrs []string) {
+	var walk func(node *html.Node) bool
+	walk = func(node *html.Node) (found bool) {
+		if len(targets) >= maxSuggestTargets {
+			return true
+		}
+		if node.Type == html.ElementNode {
+			switch node.Data {
+			case "script", "style", "noscript", "template":
+				return false
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if walk(child) {
+				found = true
+			}
+		}
+		if found || node.Type != html.ElementNode {
+			return found
+		}
+
+		if strings.Contains(strings.ToLower(normalizeSpaces(nodeText(node))), example) {
+			targets, attrs = append(targets, node), append(attrs, "")
+			return true
+		}
+		for _, attr := range node.Attr {
+			for _, name := range suggestAttrs {
+				if attr.Key == name && strings.Contains(strings.ToLower(normalizeSpaces(attr.Val)), example) {
+					targets, attrs = append(targets, node), append(attrs, name)
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	for _, node := range doc.doc.Find("*").Nodes {
+		if node.Parent == nil || node.Parent.Type == html.DocumentNode {
+			walk(node)
+		}
+	}
+
+	return targets, attrs
+}
+
+// nodeText - text of node without script and style
+func nodeText(node *html.Node) string {
+	buf := strings.Builder{}
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		switch {
+		case node.Type == html.TextNode:
+			buf.WriteString(node.Data)
+		case node.Type == html.ElementNode && (node.Data == "script" || node.Data == "style"):
+			return
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	return buf.String()
+}
+
+// suggestCandidates - selectors for element: by itself and with context of ancestors
+func (doc Doc) suggestCandidates(target *html.Node, attr string) (candidates []suggestCandidate) {
+	own := doc.elementSelectors(target)
+	for _, candidate := range own {
+		candidate.attr = attr
+		candidates = append(candidates, candidate)
+	}
+
+	depth := 0
+	for ancestor := target.Parent; ancestor != nil && ancestor.Type == html.ElementNode && depth < maxSuggestAncestors; ancestor = ancestor.Parent {
+		context := []suggestCandidate{}
+		for _, candidate := range doc.elementSelectors(ancestor) {
+			if candidate.kind != suggestKindTag {
+				context = append(context, candidate)
+			}
+		}
+		if len(context) == 0 {
+			continue
+		}
+		depth++
+
+		for _, outer := range context {
+			for _, inner := range own {
+				if inner.kind == suggestKindID {
+					continue
+				}
+				kind := inner.kind
+				if outer.kind < kind {
+					kind = outer.kind
+				}
+				candidates = append(candidates, suggestCandidate{
+					selector:    outer.selector + " " + inner.selector,
+					inner:       inner.selector,
+					attr:        attr,
+					kind:        kind,
+					parts:       2,
+					specificity: outer.specificity + inner.specificity,
+				})
+			}
+		}
+	}
+
+	return candidates
+}
+
+// elementSelectors - simple selectors of element: #id, tag[data-attr="value"], tag.class, tag
+func (doc Doc) elementSelectors(node *html.Node) (result []suggestCandidate) {
+	tag := node.Data
+	if doc.xml {
+		tag = decodeXMLName(tag)
+	}
+	if !suggestNameRe.MatchString(tag) {
+		return nil
+	}
+
+	for _, attr := range node.Attr {
+		value := strings.TrimSpace(attr.Val)
+		switch {
+		case attr.Key == "id" && isStableName(value):
+			result = append(result, suggestCandidate{selector: "#" + value, kind: suggestKindID, parts: 1, specificity: 100})
+		case isSuggestAttr(attr.Key) && !doc.xml && isStableValue(value):
+			result = append(result, suggestCandidate{selector: tag + "[" + attr.Key + `="` + value + `"]`, kind: suggestKindAttr, parts: 1, specificity: 11})
+		case attr.Key == "class" && !doc.xml:
+			for _, class := range strings.Fields(value) {
+				if isStableName(class) {
+					result = append(result, suggestCandidate{selector: tag + "." + class, kind: suggestKindClass, parts: 1, specificity: 11})
+				}
+			}
+		}
+	}
+
+	return append(result, suggestCandidate{selector: tag, kind: suggestKindTag, parts: 1, specificity: 1})
+}
+
+// isSuggestAttr - attribute which identifies element: data-attributes, microdata, name of meta and inputs
+func isSuggestAttr(name string) bool {
+	switch name {
+	case "itemprop", "name", "property", "rel", "role":
+		return true
+	}
+
+	return strings.HasPrefix(name, "data-") && suggestNameRe.MatchString(name)
+}
+
+// isStableName - id or class is not generated: not a hash, not a number
+func isStableName(name string) bool {
+	return suggestNameRe.MatchString(name) && isStableValue(name)
+}
+
+// isStableValue - value of attribute is not generated and can be used in selector without escaping
+func isStableValue(value string) bool {
+	if value == "" || len(value) > 40 || strings.ContainsAny(value, "\"\\\n") || generatedNameRe.MatchString(value) {
+		return false
+	}
+
+	digits := 0
+	for _, char := range value {
+		if char >= '0' && char <= '9' {
+			digits++
+		}
+	}
+
+	return digits < 3
+}
+
+// suggestMatches - count of elements found by selector, ok if target is found and all elements are similar to it
+func (doc Doc) suggestMatches(selector string, target *html.Node) (matches int, ok bool) {
+	defer func() {
+		if recover() != nil {
+			matches, ok = 0, false
+		}
+	}()
+
+	found := false
+	nodes := doc.doc.Find(doc.findSelector(selector)).Nodes
+	for _, node := range nodes {
+		if node == target {
+			found = true
+		}
+		if nodeSignature(node) != nodeSignature(target) {
+			return 0, false
+		}
+	}
+
+	return len(nodes), found
+}
+
+// nodeSignature - tag, sorted classes and identifying attributes of element, the same for similar elements
+func nodeSignature(node *html.Node) string {
+	signature := []string{}
+	for _, attr := range node.Attr {
+		switch {
+		case attr.Key == "class":
+			classes := strings.Fields(attr.Val)
+			sort.Strings(classes)
+			signature = append(signature, "."+strings.Join(classes, "."))
+		case attr.Key == "itemprop" || attr.Key == "name" || attr.Key == "property":
+			signature = append(signature, attr.Key+"="+attr.Val)
+		}
+	}
+	sort.Strings(signature)
+
+	return node.Data + " " + strings.Join(signature, " ")
+}
@@ -0,0 +1,120 @@
+package html2data
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_SuggestSelectors(t *testing.T) {
+	page := `<html><head>
+		<title>Shop</title>
+		<meta property="og:title" content="Example Product Name">
+		<meta name="description" content="Best products">
+	</head><body>
+		<h1 id="page-title">Catalog</h1>
+		<ul class="products">
+			<li class="product css-1x2y3z"><a class="product-name" data-role="name" href="/1">Example Product Name</a> <span class="price">10</span></li>
+			<li class="product css-4a5b6c"><a class="product-name" data-role="name" href="/2">Other Product</a> <span class="price">20</span></li>
+		</ul>
+		<div id="x18276364">Unique text</div>
+		<script>var title = "Example Product Name";</script>
+	</body></html>`
+	doc := FromReader(strings.NewReader(page))
+
+	testData := []struct {
+		example  string
+		expected []string
+		err      bool
+	}{
+		{
+			example: "example product NAME",
+			expected: []string{
+				`a[data-role="name"]`,
+				`meta[property="og:title"]:attr(content)`,
+				`a.product-name`,
+				`a`,
+			},
+		},
+		{
+			example:  "Catalog",
+			expected: []string{"#page-title", "h1"},
+		},
+		{
+			example:  "Unique text",
+			expected: []string{"div"},
+		},
+		{
+			example: "not found",
+			err:     true,
+		},
+		{
+			example: "  ",
+			err:     true,
+		},
+	}
+
+	for i, item := range testData {
+		suggestions, err := SuggestSelectors(doc, item.example)
+		if item.err {
+			if err == nil {
+				t.Errorf("%d. SuggestSelectors(%q): expected error", i, item.example)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d. SuggestSelectors(%q): %s", i, item.example, err)
+			continue
+		}
+
+		selectors := []string{}
+		for _, suggestion := range suggestions {
+			selectors = append(selectors, suggestion.Selector)
+		}
+		if !reflect.DeepEqual(selectors, item.expected) {
+			t.Errorf("%d. SuggestSelectors(%q):\nexpected: %q\nreal:     %q", i, item.example, item.expected, selectors)
+		}
+	}
+
+	// context of ancestor for find only one of similar elements
+	doc = FromReader(strings.NewReader(`<div id="old"><span class="v">1</span></div><div id="new"><span class="v">2</span></div>`))
+	suggestions, err := SuggestSelectors(doc, "2")
+	selectors := []string{}
+	for _, suggestion := range suggestions {
+		selectors = append(selectors, suggestion.Selector)
+	}
+	if err != nil || !reflect.DeepEqual(selectors, []string{"#new span", "#new span.v", "span.v", "span"}) {
+		t.Errorf("SuggestSelectors() with context: got: %q, %v", selectors, err)
+	}
+
+	doc = FromReader(strings.NewReader(page))
+	suggestions, err = SuggestSelectors(doc, "Example Product Name")
+	if err != nil || len(suggestions) < 2 ||
+		!reflect.DeepEqual(suggestions[0], SelectorSuggestion{Selector: `a[data-role="name"]`, Specificity: 11, Matches: 2, Values: []string{"Example Product Name", "Other Product"}}) {
+		t.Errorf("SuggestSelectors(): got: %#v, %v", suggestions, err)
+	}
+}
+
+func Test_isStableName(t *testing.T) {
+	testData := []struct {
+		name     string
+		expected bool
+	}{
+		{"product-title", true},
+		{"col-md-6", true},
+		{"h2", true},
+		{"css-1x2y3z", false},
+		{"sc-bdVaJa", false},
+		{"Title_title__3xYz1", false},
+		{"item-123456", false},
+		{"a1b2c3", false},
+		{"has space", false},
+		{"", false},
+	}
+
+	for i, item := range testData {
+		if real := isStableName(item.name); real != item.expected {
+			t.Errorf("%d. isStableName(%q): expected: %v, real: %v", i, item.name, item.expected, real)
+		}
+	}
+}
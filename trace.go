@@ -0,0 +1,202 @@
+package html2data
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// SelectorTrace - report of one selector for Cfg.Trace: how selector is parsed and what it matched
+type SelectorTrace struct {
+	Name            string      // name of selector, empty for outer selector of GetDataNested*()
+	Selector        string      // selector as is
+	BaseSelector    string      // CSS selector without pseudo-selectors of html2data
+	PseudoSelectors []string    // pseudo-selectors of html2data: "attr(href)", "get(2)", "int"...
+	Outer           string      // DOM path of outer element for nested selectors
+	Nodes           []TraceNode // all nodes matched by base selector
+}
+
+// TraceNode - node matched by selector
+type TraceNode struct {
+	Path    string        // DOM path of node
+	Line    int           // line of start tag in source, 0 if unknown
+	Column  int           // column (in chars) of start tag in source, 0 if unknown
+	Skipped bool          // node is filtered out by :get(N)
+	Values  []interface{} // values extracted from node, nil for skipped nodes
+}
+
+// docSource - source of document for find positions of elements, positions are calculated on first use
+type docSource struct {
+	data      []byte
+	xml       bool
+	once      sync.Once
+	positions map[*html.Node][2]int // line and column of element
+}
+
+// pseudoSelectors - pseudo-selectors of html2data in the canonical form
+func (selector CSSSelector) pseudoSelectors() (result []string) {
+	if selector.attrName != "" {
+		result = append(result, "attr("+selector.attrName+")")
+	}
+	for name, ok := range map[string]bool{"html": selector.getHTML, "outerhtml": selector.getOuterHTML, "cleanhtml": selector.getCleanHTML} {
+		if ok {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	if selector.getJSON {
+		result = append(result, "json("+selector.jsonPath+")")
+	}
+	if selector.getNth > 0 {
+		result = append(result, "get("+strconv.Itoa(selector.getNth)+")")
+	}
+	switch {
+	case selector.valueType != "" && selector.valueArg != "":
+		result = append(result, selector.valueType+"("+selector.valueArg+")")
+	case selector.valueType != "":
+		result = append(result, selector.valueType)
+	}
+
+	return result
+}
+
+// newSelectorTrace - start trace of selector, outer selection is used for nested selectors
+func (doc Doc) newSelectorTrace(name, selectorRaw string, selector CSSSelector, docOrSelection docOrSelection) *SelectorTrace {
+	trace := &SelectorTrace{
+		Name:            name,
+		Selector:        selectorRaw,
+		BaseSelector:    selector.selector,
+		PseudoSelectors: selector.pseudoSelectors(),
+		Nodes:           []TraceNode{},
+	}
+	if outer, ok := docOrSelection.(*goquery.Selection); ok && len(outer.Nodes) > 0 {
+		trace.Outer = doc.nodePath(outer.Nodes[0])
+	}
+
+	return trace
+}
+
+// addNode - add matched node to trace
+func (trace *SelectorTrace) addNode(doc Doc, node *html.Node, skipped bool, values []interface{}) {
+	line, column := doc.nodePosition(node)
+	trace.Nodes = append(trace.Nodes, TraceNode{
+		Path:    doc.nodePath(node),
+		Line:    line,
+		Column:  column,
+		Skipped: skipped,
+		Values:  values,
+	})
+}
+
+// nodePosition - line and column of element in source, 0 if unknown
+func (doc Doc) nodePosition(node *html.Node) (line, column int) {
+	if doc.source == nil {
+		return 0, 0
+	}
+
+	doc.source.once.Do(func() {
+		root := node
+		for root.Parent != nil {
+			root = root.Parent
+		}
+		doc.source.positions = sourcePositions(doc.source.data, root, doc.source.xml)
+	})
+
+	position := doc.source.positions[node]
+	return position[0], position[1]
+}
+
+// sourcePositions - find positions of elements in source: n-th start tag with some name in source
+// is n-th element with that name in document. Parser of HTML adds missing elements (html, body, tbody...)
+// and fixes wrong nesting, so positions are found only for names with the same count of elements in source and document
+func sourcePositions(data []byte, root *html.Node, isXML bool) map[*html.Node][2]int {
+	var offsets map[string][]int
+	if isXML {
+		offsets = xmlTagOffsets(data)
+	} else {
+		offsets = htmlTagOffsets(data)
+	}
+
+	nodes := map[string][]*html.Node{}
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode {
+			name := node.Data
+			if !isXML {
+				name = strings.ToLower(name)
+			}
+			nodes[name] = append(nodes[name], node)
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	lineStarts := []int{0}
+	for i, char := range data {
+		if char == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+
+	positions := map[*html.Node][2]int{}
+	for name, list := range nodes {
+		if len(offsets[name]) != len(list) {
+			continue
+		}
+		for i, node := range list {
+			offset := offsets[name][i]
+			line := sort.Search(len(lineStarts), func(j int) bool { return lineStarts[j] > offset })
+			positions[node] = [2]int{line, utf8.RuneCount(data[lineStarts[line-1]:offset]) + 1}
+		}
+	}
+
+	return positions
+}
+
+// htmlTagOffsets - offsets of start tags in HTML source by tag name
+func htmlTagOffsets(data []byte) map[string][]int {
+	offsets := map[string][]int{}
+	tokenizer := html.NewTokenizer(bytes.NewReader(data))
+	offset := 0
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			return offsets
+		}
+		if tokenType == html.StartTagToken || tokenType == html.SelfClosingTagToken {
+			name, _ := tokenizer.TagName()
+			offsets[string(name)] = append(offsets[string(name)], offset)
+		}
+		offset += len(tokenizer.Raw())
+	}
+}
+
+// xmlTagOffsets - offsets of start elements in XML source by encoded name, the same way as parseXML
+func xmlTagOffsets(data []byte) map[string][]int {
+	offsets := map[string][]int{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.RawToken()
+		if err != nil {
+			return offsets
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			name := encodeXMLName(xmlName(start.Name))
+			offsets[name] = append(offsets[name], offset)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package html2data
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// constructors without config keep their signatures for use as function values
+var (
+	_ func(io.Reader) Doc = FromReader
+	_ func(io.Reader) Doc = FromReaderXML
+	_ func(string) Doc    = FromFile
+)
+
+func Test_Trace(t *testing.T) {
+	page := "<div id=\"main\">\n  <a href=\"/1\">one</a>\n  <a href=\"/2\">two</a>\n</div>\n<p>Ü <a href=\"/3\">three</a></p>"
+	doc := FromReaderCfg(strings.NewReader(page), ReaderCfg{KeepSource: true})
+
+	traces := []SelectorTrace{}
+	trace := func(trace SelectorTrace) { traces = append(traces, trace) }
+	_, err := doc.GetDataTyped(map[string]string{"links": "div a:get(2):attr(href)", "no": "h1:int"}, Cfg{Trace: trace})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []SelectorTrace{
+		{
+			Name:            "links",
+			Selector:        "div a:get(2):attr(href)",
+			BaseSelector:    "div a",
+			PseudoSelectors: []string{"attr(href)", "get(2)"},
+			Nodes: []TraceNode{
+				{Path: "html > body > div#main > a:nth-of-type(1)", Line: 2, Column: 3, Skipped: true},
+				{Path: "html > body > div#main > a:nth-of-type(2)", Line: 3, Column: 3, Values: []interface{}{"/2"}},
+			},
+		},
+		{
+			Name:            "no",
+			Selector:        "h1:int",
+			BaseSelector:    "h1",
+			PseudoSelectors: []string{"int"},
+			Nodes:           []TraceNode{},
+		},
+	}
+	if !reflect.DeepEqual(traces, expected) {
+		t.Errorf("Trace:\nexpected: %#v\nreal:     %#v", expected, traces)
+	}
+
+	traces = traces[:0]
+	_, err = doc.GetDataNested("div, p", map[string]string{"link": "a:get(1)"}, Cfg{Trace: trace})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nestedNodes := []TraceNode{
+		{Path: "html > body > p > a", Line: 5, Column: 6, Values: []interface{}{"three"}},
+	}
+	if len(traces) != 3 ||
+		traces[0].Name != "" || traces[0].BaseSelector != "div, p" || len(traces[0].Nodes) != 2 ||
+		traces[1].Outer != "html > body > div#main" || len(traces[1].Nodes) != 2 || !traces[1].Nodes[1].Skipped ||
+		traces[2].Outer != "html > body > p" || !reflect.DeepEqual(traces[2].Nodes, nestedNodes) {
+		t.Errorf("Trace for nested: got: %#v", traces)
+	}
+
+	// without source positions are unknown
+	traces = traces[:0]
+	if _, err = FromReader(strings.NewReader(page)).GetData(map[string]string{"p": "p"}, Cfg{Trace: trace}); err != nil {
+		t.Fatal(err)
+	}
+	if len(traces) != 1 || !reflect.DeepEqual(traces[0].Nodes, []TraceNode{{Path: "html > body > p", Values: []interface{}{"Ü three"}}}) {
+		t.Errorf("Trace without source: got: %#v", traces)
+	}
+
+	// tbody is added by parser, so its position is unknown
+	doc = FromReaderCfg(strings.NewReader("<table>\n<tr><td>1</td></tr></table>"), ReaderCfg{KeepSource: true})
+	traces = traces[:0]
+	if _, err = doc.GetData(map[string]string{"td": "tbody td"}, Cfg{Trace: trace}); err != nil {
+		t.Fatal(err)
+	}
+	if len(traces) != 1 || len(traces[0].Nodes) != 1 || traces[0].Nodes[0].Line != 2 || traces[0].Nodes[0].Column != 5 {
+		t.Errorf("Trace with implied elements: got: %#v", traces)
+	}
+	if line, column := doc.nodePosition(doc.doc.Find("tbody").Nodes[0]); line != 0 || column != 0 {
+		t.Errorf("position of tbody: expected unknown, got: %d:%d", line, column)
+	}
+
+	xmlDoc := FromReaderCfg(strings.NewReader("<rss>\n<item><pubDate>1</pubDate></item>\n<item><pubDate>2</pubDate></item></rss>"), ReaderCfg{XML: true, KeepSource: true})
+	traces = traces[:0]
+	if _, err = xmlDoc.GetData(map[string]string{"date": "pubDate"}, Cfg{Trace: trace}); err != nil {
+		t.Fatal(err)
+	}
+	if len(traces) != 1 || len(traces[0].Nodes) != 2 ||
+		traces[0].Nodes[1].Path != "rss > item:nth-of-type(2) > pubDate" || traces[0].Nodes[1].Line != 3 || traces[0].Nodes[1].Column != 7 {
+		t.Errorf("Trace for XML: got: %#v", traces)
+	}
+}
+
+func Test_pseudoSelectors(t *testing.T) {
+	testData := []struct {
+		selector string
+		expected []string
+	}{
+		{"div", nil},
+		{"div:first-child", nil},
+		{"a:attr(href):get(3)", []string{"attr(href)", "get(3)"}},
+		{"div:outerhtml", []string{"outerhtml"}},
+		{"script:json(a.b):number(,)", []string{"json(a.b)", "number(,)"}},
+		{"time:date(2006-01-02)", []string{"date(2006-01-02)"}},
+	}
+
+	for i, item := range testData {
+		if real := parseSelector(item.selector).pseudoSelectors(); !reflect.DeepEqual(real, item.expected) {
+			t.Errorf("%d. pseudoSelectors(%q): expected: %v, real: %v", i, item.selector, item.expected, real)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package html2data
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeNow - current time for relative dates, for mock in tests
+var timeNow = time.Now
+
+// dateLayouts - layouts for :date without layout
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"January 2, 2006 15:04",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	"2006/01/02",
+}
+
+var (
+	numberRe       = regexp.MustCompile(`[-−]?\d(?:[\d.,'’ \x{00a0}\x{202f}]*\d)?`)
+	thousandsSepRe = regexp.MustCompile(`[ '’\x{00a0}\x{202f}]`)
+	thousandsRe    = regexp.MustCompile(`^\d{3}(?:[.,]|$)`)
+	relativeDateRe = regexp.MustCompile(`^(\d+|an?|one)\s+(second|sec|minute|min|hour|day|week|month|year)s?\s+ago$`)
+)
+
+// convertValue - convert found text to typed value by pseudo-selector,
+// returns nil if text can't be converted
+func (selector CSSSelector) convertValue(text string, config Cfg) interface{} {
+	if selector.valueType == "" {
+		return text
+	}
+
+	text = strings.TrimSpace(text)
+	switch selector.valueType {
+	case "number", "int":
+		decimalSeparator := selector.valueArg
+		if decimalSeparator == "" {
+			decimalSeparator = config.DecimalSeparator
+		}
+		number, ok := parseNumber(text, decimalSeparator)
+		if !ok {
+			return nil
+		}
+		if selector.valueType == "int" {
+			// float64(math.MaxInt64) is 2^63, which is already out of int64 range
+			if number != math.Trunc(number) || number < math.MinInt64 || number >= math.MaxInt64 {
+				return nil
+			}
+			return int64(number)
+		}
+		return number
+	case "bool":
+		value, ok := parseBool(text)
+		if !ok {
+			return nil
+		}
+		return value
+	case "date":
+		value, ok := parseDate(text, selector.valueArg, config.Location)
+		if !ok {
+			return nil
+		}
+		return value
+	}
+
+	return text
+}
+
+// parseNumber - get first number from text with thousands separators:
+// "1 234,50 €" -> 1234.5, "$1,234.50" -> 1234.5, "1.234" -> 1234, "1,5" -> 1.5
+func parseNumber(text string, decimalSeparator string) (float64, bool) {
+	found := numberRe.FindString(text)
+	if found == "" {
+		return 0, false
+	}
+	found = strings.Replace(found, "−", "-", 1)
+
+	// spaces and apostrophes are thousands separators only before groups of 3 digits
+	chunks := thousandsSepRe.Split(found, -1)
+	found = chunks[0]
+	for _, chunk := range chunks[1:] {
+		if !thousandsRe.MatchString(chunk) {
+			break
+		}
+		found += chunk
+	}
+	found = strings.TrimRight(found, ".,")
+
+	lastDot, lastComma := strings.LastIndex(found, "."), strings.LastIndex(found, ",")
+	switch {
+	case decimalSeparator == "." || decimalSeparator == ",":
+		// see below
+	case lastDot < 0 && lastComma < 0:
+		decimalSeparator = "."
+	case lastDot >= 0 && lastComma >= 0 && lastDot > lastComma:
+		decimalSeparator = "."
+	case lastDot >= 0 && lastComma >= 0:
+		decimalSeparator = ","
+	case lastDot >= 0:
+		decimalSeparator = guessDecimalSeparator(found, ".")
+	case lastComma >= 0:
+		decimalSeparator = guessDecimalSeparator(found, ",")
+	}
+
+	thousandsSeparator := ","
+	if decimalSeparator == "," {
+		thousandsSeparator = "."
+	}
+	found = strings.ReplaceAll(found, thousandsSeparator, "")
+	found = strings.Replace(found, decimalSeparator, ".", 1)
+
+	number, err := strconv.ParseFloat(found, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
+
+// guessDecimalSeparator - guess separator role when only one kind of separator found,
+// one separator followed by exactly 3 digits is treated as thousands separator
+func guessDecimalSeparator(number string, separator string) string {
+	other := map[string]string{".": ",", ",": "."}[separator]
+	if strings.Count(number, separator) > 1 {
+		return other
+	}
+
+	parts := strings.SplitN(strings.TrimLeft(number, "-"), separator, 2)
+	if len(parts[1]) == 3 && parts[0] != "0" {
+		return other
+	}
+
+	return separator
+}
+
+// parseBool - parse boolean value from text
+func parseBool(text string) (bool, bool) {
+	switch strings.ToLower(text) {
+	case "true", "yes", "y", "on", "1", "checked", "selected", "enabled":
+		return true, true
+	case "false", "no", "n", "off", "0", "disabled":
+		return false, true
+	}
+
+	return false, false
+}
+
+// parseDate - parse date by layout or by list of common layouts and relative dates ("3 days ago")
+func parseDate(text string, layout string, location *time.Location) (time.Time, bool) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	if layout != "" {
+		date, err := time.ParseInLocation(layout, text, location)
+		return date, err == nil
+	}
+
+	for _, layout := range dateLayouts {
+		if date, err := time.ParseInLocation(layout, text, location); err == nil {
+			return date, true
+		}
+	}
+
+	return parseRelativeDate(strings.ToLower(text), location)
+}
+
+// parseRelativeDate - parse "now", "today", "yesterday", "N days ago"
+func parseRelativeDate(text string, location *time.Location) (time.Time, bool) {
+	now := timeNow().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+
+	switch text {
+	case "now", "just now":
+		return now, true
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+
+	reParts := relativeDateRe.FindStringSubmatch(text)
+	if len(reParts) != 3 {
+		return time.Time{}, false
+	}
+
+	count, err := strconv.Atoi(reParts[1])
+	if err != nil {
+		count = 1 // "a", "an", "one"
+	}
+
+	switch reParts[2] {
+	case "second", "sec":
+		return now.Add(-time.Duration(count) * time.Second), true
+	case "minute", "min":
+		return now.Add(-time.Duration(count) * time.Minute), true
+	case "hour":
+		return now.Add(-time.Duration(count) * time.Hour), true
+	case "day":
+		return now.AddDate(0, 0, -count), true
+	case "week":
+		return now.AddDate(0, 0, -7*count), true
+	case "month":
+		return now.AddDate(0, -count, 0), true
+	default:
+		return now.AddDate(-count, 0, 0), true
+	}
+}
+
+// valueToText - format typed value as text
+func valueToText(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case bool:
+		return strconv.FormatBool(value)
+	case time.Time:
+		return value.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// valuesToTexts - format map with typed values as texts
+func valuesToTexts(values map[string][]interface{}) map[string][]string {
+	result := make(map[string][]string, len(values))
+	for name, list := range values {
+		texts := make([]string, 0, len(list))
+		for _, value := range list {
+			texts = append(texts, valueToText(value))
+		}
+		result[name] = texts
+	}
+
+	return result
+}
@@ -0,0 +1,118 @@
+package html2data
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseNumber(t *testing.T) {
+	testData := []struct {
+		in        string
+		separator string
+		out       float64
+		ok        bool
+	}{
+		{"1 234,50 €", "", 1234.5, true},
+		{"$1,234.50", "", 1234.5, true},
+		{"1.234.567", "", 1234567, true},
+		{"1.234", "", 1234, true},
+		{"1.234", ".", 1.234, true},
+		{"0.500", "", 0.5, true},
+		{"1,5", "", 1.5, true},
+		{"1'234'567.8 CHF", "", 1234567.8, true},
+		{"-12.5%", "", -12.5, true},
+		{"3 days 5 hours", "", 3, true},
+		{"Price: 10.", "", 10, true},
+		{"12 345", "", 12345, true},
+		{"no number", "", 0, false},
+		{"", "", 0, false},
+	}
+
+	for i, item := range testData {
+		out, ok := parseNumber(item.in, item.separator)
+		if out != item.out || ok != item.ok {
+			t.Errorf("%d. parseNumber(%q): expected: %v/%v, real: %v/%v", i, item.in, item.out, item.ok, out, ok)
+		}
+	}
+}
+
+func Test_parseDate(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 30, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	testData := []struct {
+		in     string
+		layout string
+		out    time.Time
+		ok     bool
+	}{
+		{"2024-05-01T10:20:30Z", "", time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC), true},
+		{"2024-05-01", "", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), true},
+		{"May 1, 2024", "", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), true},
+		{"01/05/2024 10:20", "02/01/2006 15:04", time.Date(2024, 5, 1, 10, 20, 0, 0, time.UTC), true},
+		{"3 days ago", "", now.AddDate(0, 0, -3), true},
+		{"an hour ago", "", now.Add(-time.Hour), true},
+		{"Yesterday", "", time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC), true},
+		{"not a date", "", time.Time{}, false},
+	}
+
+	for i, item := range testData {
+		out, ok := parseDate(item.in, item.layout, nil)
+		if !out.Equal(item.out) || ok != item.ok {
+			t.Errorf("%d. parseDate(%q): expected: %v/%v, real: %v/%v", i, item.in, item.out, item.ok, out, ok)
+		}
+	}
+}
+
+func Test_GetDataTyped(t *testing.T) {
+	html := `<div class="item"><span class="price">1 234,50 €</span><span class="count">12 reviews</span>` +
+		`<span class="count">1.5</span><span class="count">10 000 000 000 000 000 000 000 000 000 000</span>` +
+		`<input type="checkbox" value="yes"><time datetime="2024-05-01T10:00:00Z">May 1</time><b>text</b></div>` +
+		`<div class="item"><span class="price">n/a</span><time datetime="01.02.2024 10:00">Feb 1</time></div>`
+
+	doc := FromReader(strings.NewReader(html))
+	out, err := doc.GetDataTyped(map[string]string{
+		"price": "span.price:number",
+		"count": "span.count:int",
+		"check": "input:attr(value):bool",
+		"date":  "time:attr(datetime):date",
+		"day":   "time:attr(datetime):date(02.01.2006 15:04)",
+		"text":  "b",
+	})
+	if err != nil {
+		t.Errorf("GetDataTyped() got error: %s", err)
+	}
+
+	expected := map[string][]interface{}{
+		"price": {1234.5, nil},
+		"count": {int64(12), nil, nil},
+		"check": {true},
+		"date":  {time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)},
+		"day":   {nil, time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)},
+		"text":  {"text"},
+	}
+	if !reflect.DeepEqual(expected, out) {
+		t.Errorf("GetDataTyped()\nexpected: %#v\nreal    : %#v", expected, out)
+	}
+
+	texts, err := doc.GetData(map[string]string{"price": "span.price:number", "date": "time:attr(datetime):date:get(1)"})
+	if err != nil {
+		t.Errorf("GetData() got error: %s", err)
+	}
+	expectedTexts := map[string][]string{"price": {"1234.5", ""}, "date": {"2024-05-01T10:00:00Z"}}
+	if !reflect.DeepEqual(expectedTexts, texts) {
+		t.Errorf("GetData()\nexpected: %#v\nreal    : %#v", expectedTexts, texts)
+	}
+
+	nested, err := doc.GetDataNestedTyped("div.item", map[string]string{"price": "span.price:number(,)"})
+	if err != nil {
+		t.Errorf("GetDataNestedTyped() got error: %s", err)
+	}
+	expectedNested := []map[string][]interface{}{{"price": {1234.5}}, {"price": {nil}}}
+	if !reflect.DeepEqual(expectedNested, nested) {
+		t.Errorf("GetDataNestedTyped()\nexpected: %#v\nreal    : %#v", expectedNested, nested)
+	}
+}
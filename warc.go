@@ -0,0 +1,193 @@
+package html2data
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// warcMaxBodySize - max size of HTML body of WARC record, larger records return ErrBodyTooLarge in Doc.Err
+var warcMaxBodySize int64 = 64 << 20
+
+// ErrStopArchive - return it from extract function for stop ReadWARC() or ReadHAR() without error
+var ErrStopArchive = errors.New("stop archive")
+
+// ArchiveRecord - HTML response from web archive
+type ArchiveRecord struct {
+	URL        string      // target URI of response
+	Date       time.Time   // date of response capture
+	StatusCode int         // http status, 0 for WARC resource records
+	Header     http.Header // headers of http response
+	Doc        Doc         // parsed document with URL of record, or error of parsing in Doc.Err
+}
+
+// ReadWARC - read WARC file (gzip compressed too) and call extract for each record with HTML response
+// (WARC-Type "response" or "resource"), other records are skipped
+//
+//	file, err := os.Open("crawl.warc.gz")
+//	...
+//	err = html2data.ReadWARC(file, func(record html2data.ArchiveRecord) error {
+//		title, err := record.Doc.GetDataSingle("title")
+//		...
+//	})
+func ReadWARC(reader io.Reader, extract func(ArchiveRecord) error) error {
+	reader, err := decompress(reader)
+	if err != nil {
+		return err
+	}
+
+	bufReader := bufio.NewReader(reader)
+	for {
+		record, ok, err := readWARCRecord(bufReader)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		if record == nil {
+			continue
+		}
+
+		if err := extract(*record); err != nil {
+			if errors.Is(err, ErrStopArchive) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// readWARCRecord - read next record, returns nil record for non-HTML records and false at the end of file
+func readWARCRecord(bufReader *bufio.Reader) (record *ArchiveRecord, ok bool, err error) {
+	// skip empty lines between records
+	line := ""
+	for line == "" {
+		line, err = bufReader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" && err == io.EOF:
+			return nil, false, nil
+		case err != nil && err != io.EOF:
+			return nil, false, fmt.Errorf("read WARC record: %s", err)
+		}
+	}
+	if !strings.HasPrefix(line, "WARC/") {
+		return nil, false, fmt.Errorf("invalid WARC record: %q", line)
+	}
+
+	mimeHeader, err := textproto.NewReader(bufReader).ReadMIMEHeader()
+	if err != nil {
+		return nil, false, fmt.Errorf("read WARC headers: %s", err)
+	}
+	warcHeader := http.Header(mimeHeader)
+
+	length, err := strconv.ParseInt(warcHeader.Get("Content-Length"), 10, 64)
+	if err != nil || length < 0 {
+		return nil, false, fmt.Errorf("invalid WARC Content-Length: %q", warcHeader.Get("Content-Length"))
+	}
+
+	// the rest of block is skipped after reading of HTML, and non-HTML blocks are skipped without buffering
+	block := &io.LimitedReader{R: bufReader, N: length}
+	record, err = readWARCBlock(warcHeader, block)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := io.Copy(io.Discard, block); err != nil {
+		return nil, false, fmt.Errorf("read WARC block: %s", err)
+	}
+	if block.N > 0 {
+		return nil, false, fmt.Errorf("read WARC block: %s", io.ErrUnexpectedEOF)
+	}
+
+	return record, true, nil
+}
+
+// readWARCBlock - read HTML response or resource from block of record, nil record for non-HTML blocks
+func readWARCBlock(warcHeader http.Header, block io.Reader) (record *ArchiveRecord, err error) {
+	warcType, contentType := warcHeader.Get("WARC-Type"), warcHeader.Get("Content-Type")
+	isHTTPResponse := warcType == "response" && strings.HasPrefix(contentType, "application/http")
+	if !isHTTPResponse && !(warcType == "resource" && isHTMLContentType(contentType)) {
+		return nil, nil
+	}
+
+	record = &ArchiveRecord{URL: strings.Trim(warcHeader.Get("WARC-Target-URI"), "<>")}
+	record.Date, _ = time.Parse(time.RFC3339, warcHeader.Get("WARC-Date"))
+
+	page := fetchedPage{URL: record.URL, Header: http.Header{"Content-Type": {contentType}}}
+	body := block
+	if isHTTPResponse {
+		// headers of http response are read first, for skip non-HTML body without reading
+		response, err := http.ReadResponse(bufio.NewReader(block), nil)
+		if err != nil {
+			record.Doc = Doc{Err: fmt.Errorf("parse http response: %s", err), URL: record.URL}
+			return record, nil
+		}
+		defer func() { _ = response.Body.Close() }()
+
+		page.StatusCode, page.Header = response.StatusCode, response.Header
+		bodyReader := bufio.NewReader(response.Body)
+		if page.Header.Get("Content-Type") == "" {
+			// Content-Type is detected by the first 512 bytes of body
+			sniff, _ := bodyReader.Peek(512)
+			page.Body = sniff
+		}
+		if !page.isHTML() {
+			return nil, nil
+		}
+		record.StatusCode, record.Header = page.StatusCode, page.Header
+		body = bodyReader
+	}
+
+	page.Body, err = io.ReadAll(io.LimitReader(body, warcMaxBodySize+1))
+	// body of archived response may be truncated
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		if isHTTPResponse {
+			record.Doc = Doc{Err: fmt.Errorf("read http response: %s", err), URL: record.URL}
+			return record, nil
+		}
+		return nil, fmt.Errorf("read WARC block: %s", err)
+	}
+	if int64(len(page.Body)) > warcMaxBodySize {
+		record.Doc = Doc{Err: fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, warcMaxBodySize), URL: record.URL}
+		return record, nil
+	}
+
+	record.Doc = page.doc()
+	return record, nil
+}
+
+// doc - parse document from page with decoding of body
+func (page fetchedPage) doc() Doc {
+	htmlReader, err := page.htmlReader(false)
+	if err != nil {
+		return Doc{Err: err, URL: page.URL}
+	}
+
+	doc := FromReader(htmlReader)
+	doc.URL = page.URL
+	return doc
+}
+
+// isHTML - check that page is HTML by Content-Type or by content if Content-Type is empty
+func (page fetchedPage) isHTML() bool {
+	contentType := page.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(page.Body)
+	}
+
+	return isHTMLContentType(contentType)
+}
+
+// isHTMLContentType - check that Content-Type is HTML or XHTML
+func isHTMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "text/html" || mediaType == "application/xhtml+xml")
+}
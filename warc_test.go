@@ -0,0 +1,117 @@
+package html2data
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func warcRecord(warcType, uri, contentType, block string) string {
+	return fmt.Sprintf("WARC/1.0\r\nWARC-Type: %s\r\nWARC-Target-URI: %s\r\nWARC-Date: 2024-01-02T03:04:05Z\r\n"+
+		"Content-Type: %s\r\nContent-Length: %d\r\n\r\n%s\r\n\r\n", warcType, uri, contentType, len(block), block)
+}
+
+func testWARCRecords() []string {
+	return []string{
+		warcRecord("warcinfo", "", "application/warc-fields", "software: test\r\n"),
+		warcRecord("request", "http://example.com/", "application/http; msgtype=request", "GET / HTTP/1.1\r\nHost: example.com\r\n\r\n"),
+		warcRecord("response", "http://example.com/", "application/http; msgtype=response",
+			"HTTP/1.1 200 OK\r\nContent-Type: text/html; charset=utf-8\r\nX-Test: 1\r\n\r\n<title>Index</title><a href=\"/about\">about</a>"),
+		warcRecord("response", "http://example.com/logo.png", "application/http; msgtype=response",
+			"HTTP/1.1 200 OK\r\nContent-Type: image/png\r\n\r\n\x89PNG"),
+		warcRecord("response", "<http://example.com/chunked>", "application/http; msgtype=response",
+			"HTTP/1.1 404 Not Found\r\nContent-Type: text/html\r\nTransfer-Encoding: chunked\r\n\r\n12\r\n<title>404</title>\r\n0\r\n\r\n"),
+		warcRecord("resource", "http://example.com/saved", "text/html", "<title>Saved</title>"),
+	}
+}
+
+func Test_ReadWARC(t *testing.T) {
+	records := testWARCRecords()
+
+	gzipped := bytes.Buffer{}
+	for _, record := range records {
+		// each record is separate gzip member
+		gzipped.Write(gzipBytes(t, record))
+	}
+
+	for name, content := range map[string][]byte{"plain": []byte(strings.Join(records, "")), "gzip": gzipped.Bytes()} {
+		result := []string{}
+		err := ReadWARC(bytes.NewReader(content), func(record ArchiveRecord) error {
+			title, err := record.Doc.GetDataSingle("title")
+			result = append(result, fmt.Sprintf("%s %d %s", record.URL, record.StatusCode, title))
+			if record.URL == "http://example.com/" {
+				if record.Header.Get("X-Test") != "1" || !record.Date.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+					t.Errorf("%s: ReadWARC() record: %#v", name, record)
+				}
+				links, _ := record.Doc.Links()
+				if len(links) != 1 || links[0].URL != "http://example.com/about" {
+					t.Errorf("%s: ReadWARC() links: %#v", name, links)
+				}
+			}
+			return err
+		})
+		if err != nil {
+			t.Errorf("%s: ReadWARC() got error: %s", name, err)
+		}
+
+		expected := []string{"http://example.com/ 200 Index", "http://example.com/chunked 404 404", "http://example.com/saved 0 Saved"}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("%s: ReadWARC()\nexpected: %#v\nreal    : %#v", name, expected, result)
+		}
+	}
+
+	count := 0
+	err := ReadWARC(strings.NewReader(strings.Join(records, "")), func(ArchiveRecord) error {
+		count++
+		return ErrStopArchive
+	})
+	if err != nil || count != 1 {
+		t.Errorf("ReadWARC() with ErrStopArchive: got: %d, %v", count, err)
+	}
+
+	for _, content := range []string{
+		"not warc",
+		"WARC/1.0\r\nContent-Length: 100\r\n\r\nshort",
+		"WARC/1.0\r\nContent-Length: x\r\n\r\n",
+		"WARC/1.0\r\nContent-Length: -1\r\n\r\n",
+		// huge Content-Length is not allocated
+		"WARC/1.0\r\nWARC-Type: response\r\nContent-Type: application/http\r\nContent-Length: 1099511627776\r\n\r\nHTTP/1.1 200 OK\r\nContent-Type: text/html\r\n\r\n<p>",
+		"WARC/1.0\r\nWARC-Type: response\r\nContent-Type: application/http\r\nContent-Length: 1099511627776\r\n\r\nHTTP/1.1 200 OK\r\nContent-Type: video/mp4\r\n\r\n...",
+	} {
+		if err := ReadWARC(strings.NewReader(content), func(ArchiveRecord) error { return nil }); err == nil {
+			t.Errorf("ReadWARC(%q): error expected", content)
+		}
+	}
+}
+
+func Test_ReadWARCMaxBodySize(t *testing.T) {
+	defer func(size int64) { warcMaxBodySize = size }(warcMaxBodySize)
+	warcMaxBodySize = 30
+
+	records := strings.Join([]string{
+		warcRecord("response", "http://example.com/large", "application/http; msgtype=response",
+			"HTTP/1.1 200 OK\r\nContent-Type: text/html\r\n\r\n<title>Large</title>"+strings.Repeat("<p>text</p>", 10)),
+		warcRecord("response", "http://example.com/video", "application/http; msgtype=response",
+			"HTTP/1.1 200 OK\r\nContent-Type: video/mp4\r\n\r\n"+strings.Repeat("x", 1000)),
+		warcRecord("response", "http://example.com/small", "application/http; msgtype=response",
+			"HTTP/1.1 200 OK\r\n\r\n<title>Small</title>"),
+	}, "")
+
+	result := []string{}
+	err := ReadWARC(strings.NewReader(records), func(record ArchiveRecord) error {
+		title, _ := record.Doc.GetDataSingle("title")
+		if errors.Is(record.Doc.Err, ErrBodyTooLarge) {
+			title = "too large"
+		}
+		result = append(result, record.URL+" "+title)
+		return nil
+	})
+	expected := []string{"http://example.com/large too large", "http://example.com/small Small"}
+	if err != nil || !reflect.DeepEqual(expected, result) {
+		t.Errorf("ReadWARC() with large records:\nexpected: %#v\nreal    : %#v, %v", expected, result, err)
+	}
+}
@@ -0,0 +1,305 @@
+package html2data
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+// xmlUpperMark - marker of uppercase letter in names of XML elements and attributes:
+// CSS parser lowercases names in selectors, so names are stored with marked uppercase letters
+// and the same encoding is applied to names in selectors
+const xmlUpperMark = '\uE000'
+
+// FromReaderXML - get doc from XML (RSS, Atom, sitemaps, any XML): names of elements and attributes
+// are case-sensitive, namespace prefixes are kept in names and can be selected by "prefix|name" or "prefix\:name"
+//
+//	doc := html2data.FromReaderXML(reader)
+//	urls, err := doc.GetData(map[string]string{"images": "media|content:attr(url)", "dates": "pubDate"})
+func FromReaderXML(reader io.Reader) Doc {
+	return FromReaderCfg(reader, ReaderCfg{XML: true})
+}
+
+// fromXML - parse XML document from decompressed reader
+func fromXML(reader io.Reader) Doc {
+	root, err := parseXML(reader)
+	if err != nil {
+		return Doc{Err: err}
+	}
+
+	return Doc{doc: goquery.NewDocumentFromNode(root), xml: true}
+}
+
+// parseXML - parse XML to tree of html.Node, names are encoded by encodeXMLName
+func parseXML(reader io.Reader) (*html.Node, error) {
+	decoder := xml.NewDecoder(reader)
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	decoder.CharsetReader = charset.NewReaderLabel
+
+	root := &html.Node{Type: html.DocumentNode}
+	stack := []*html.Node{root}
+	for {
+		// RawToken keeps namespace prefixes instead of namespace URLs
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse XML: %s", err)
+		}
+
+		parent := stack[len(stack)-1]
+		switch token := token.(type) {
+		case xml.StartElement:
+			node := &html.Node{Type: html.ElementNode, Data: encodeXMLName(xmlName(token.Name))}
+			for _, attr := range token.Attr {
+				node.Attr = append(node.Attr, html.Attribute{Key: encodeXMLName(xmlName(attr.Name)), Val: attr.Value})
+			}
+			parent.AppendChild(node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			// close element and all unclosed elements inside it
+			name := encodeXMLName(xmlName(token.Name))
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].Data == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		case xml.CharData:
+			parent.AppendChild(&html.Node{Type: html.TextNode, Data: string(token)})
+		case xml.Comment:
+			parent.AppendChild(&html.Node{Type: html.CommentNode, Data: string(token)})
+		}
+	}
+
+	return root, nil
+}
+
+// xmlName - name with namespace prefix
+func xmlName(name xml.Name) string {
+	if name.Space != "" {
+		return name.Space + ":" + name.Local
+	}
+
+	return name.Local
+}
+
+// encodeXMLName - mark uppercase letters of name, escaped chars ("\X") are kept as is
+func encodeXMLName(name string) string {
+	result := strings.Builder{}
+	escaped := false
+	for _, char := range name {
+		switch {
+		case escaped:
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char >= 'A' && char <= 'Z':
+			result.WriteRune(xmlUpperMark)
+			char += 'a' - 'A'
+		}
+		result.WriteRune(char)
+	}
+
+	return result.String()
+}
+
+// decodeXMLName - restore uppercase letters of name encoded by encodeXMLName
+func decodeXMLName(name string) string {
+	if !strings.ContainsRune(name, xmlUpperMark) {
+		return name
+	}
+
+	result := strings.Builder{}
+	upper := false
+	for _, char := range name {
+		switch {
+		case char == xmlUpperMark:
+			upper = true
+			continue
+		case upper:
+			upper = false
+			char -= 'a' - 'A'
+		}
+		result.WriteRune(char)
+	}
+
+	return result.String()
+}
+
+// xmlSelectorPseudos - pseudo-classes with selector in argument, names in it are encoded too,
+// arguments of other pseudo-classes (text of :contains, :nth-child) are kept as is
+var xmlSelectorPseudos = map[string]bool{"not": true, "has": true, "haschild": true, "is": true, "where": true}
+
+// xmlSelector - encode names of elements and attributes in CSS selector for XML document,
+// "prefix|name" is converted to "prefix\:name"
+func xmlSelector(selector string) string {
+	runes := []rune(selector)
+	result := strings.Builder{}
+	last := rune(0) // last char before current identifier
+	pseudo := ""    // name of pseudo-class before current char
+	for i := 0; i < len(runes); {
+		char := runes[i]
+		switch {
+		case char == '"' || char == '\'':
+			end := skipXMLQuoted(runes, i)
+			result.WriteString(string(runes[i:end]))
+			i, last = end, char
+		case char == '(' && last == 'a' && pseudo != "" && !xmlSelectorPseudos[strings.ToLower(pseudo)]:
+			end := i + 1
+			for depth := 1; end < len(runes) && depth > 0; {
+				switch runes[end] {
+				case '"', '\'':
+					end = skipXMLQuoted(runes, end)
+					continue
+				case '\\':
+					end++
+				case '(':
+					depth++
+				case ')':
+					depth--
+				}
+				end++
+			}
+			if end > len(runes) {
+				end = len(runes)
+			}
+			result.WriteString(string(runes[i:end]))
+			i, last = end, ')'
+
+		case isXMLIdentChar(char) || char == '\\':
+			ident, next := readXMLIdent(runes, i)
+			pseudo = ""
+			if last == ':' {
+				pseudo = ident
+			}
+			// classes, ids, pseudo-classes and attribute values are kept as is
+			if last != '.' && last != '#' && last != ':' && last != '=' {
+				if next+1 < len(runes) && runes[next] == '|' && (isXMLIdentChar(runes[next+1]) || runes[next+1] == '\\') {
+					var local string
+					local, next = readXMLIdent(runes, next+1)
+					ident += `\:` + local
+				}
+				ident = encodeXMLName(ident)
+			}
+			result.WriteString(ident)
+			i, last = next, 'a'
+		default:
+			result.WriteRune(char)
+			i, last = i+1, char
+		}
+	}
+
+	return result.String()
+}
+
+// skipXMLQuoted - position after quoted string in selector, start is position of quote
+func skipXMLQuoted(runes []rune, start int) int {
+	end := start + 1
+	for ; end < len(runes) && runes[end] != runes[start]; end++ {
+		if runes[end] == '\\' {
+			end++
+		}
+	}
+	if end >= len(runes) {
+		return len(runes)
+	}
+
+	return end + 1
+}
+
+// readXMLIdent - read CSS identifier with escaped chars from position
+func readXMLIdent(runes []rune, start int) (ident string, next int) {
+	next = start
+	for next < len(runes) {
+		switch {
+		case runes[next] == '\\' && next+1 < len(runes):
+			next += 2
+		case isXMLIdentChar(runes[next]):
+			next++
+		default:
+			return string(runes[start:next]), next
+		}
+	}
+
+	return string(runes[start:next]), next
+}
+
+// isXMLIdentChar - check char of CSS identifier
+func isXMLIdentChar(char rune) bool {
+	return char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9' ||
+		char == '-' || char == '_' || char >= 0x80
+}
+
+// findSelector - CSS selector for Find() in document
+func (doc Doc) findSelector(selector string) string {
+	if doc.xml {
+		return xmlSelector(selector)
+	}
+
+	return selector
+}
+
+// attrKey - name of attribute in document
+func (doc Doc) attrKey(name string) string {
+	if doc.xml {
+		return encodeXMLName(name)
+	}
+
+	return name
+}
+
+// xmlHTML - render inner or outer XML of selection
+func xmlHTML(selection *goquery.Selection, outer bool) string {
+	buf := bytes.Buffer{}
+	if outer {
+		for _, node := range selection.Nodes {
+			renderXML(&buf, node)
+		}
+	} else if len(selection.Nodes) > 0 {
+		for child := selection.Nodes[0].FirstChild; child != nil; child = child.NextSibling {
+			renderXML(&buf, child)
+		}
+	}
+
+	return buf.String()
+}
+
+// renderXML - render node as XML with original names
+func renderXML(buf *bytes.Buffer, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		_ = xml.EscapeText(buf, []byte(node.Data))
+	case html.CommentNode:
+		buf.WriteString("<!--" + node.Data + "-->")
+	case html.ElementNode:
+		name := decodeXMLName(node.Data)
+		buf.WriteString("<" + name)
+		for _, attr := range node.Attr {
+			buf.WriteString(" " + decodeXMLName(attr.Key) + `="`)
+			_ = xml.EscapeText(buf, []byte(attr.Val))
+			buf.WriteString(`"`)
+		}
+		if node.FirstChild == nil {
+			buf.WriteString("/>")
+			return
+		}
+		buf.WriteString(">")
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			renderXML(buf, child)
+		}
+		buf.WriteString("</" + name + ">")
+	case html.DocumentNode:
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			renderXML(buf, child)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package html2data
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testXML = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel>
+	<title>Feed</title>
+	<item>
+		<title>First</title>
+		<link>https://example.com/1</link>
+		<guid isPermaLink="false">id-1</guid>
+		<pubDate>Tue, 02 Jan 2024 03:04:05 +0000</pubDate>
+		<media:content url="https://example.com/1.jpg" medium="image"/>
+		<Item>Uppercase</Item>
+		<description><![CDATA[<p>Text &amp; <b>bold</b></p>]]></description>
+	</item>
+	<item>
+		<title>Second</title>
+		<link>https://example.com/2</link>
+		<dc:date>2024-01-03T00:00:00Z</dc:date>
+	</item>
+</channel>
+</rss>`
+
+func Test_FromReaderXML(t *testing.T) {
+	doc := FromReaderXML(strings.NewReader(testXML))
+	if doc.Err != nil {
+		t.Fatalf("FromReaderXML() got error: %s", doc.Err)
+	}
+
+	testData := []struct {
+		selector string
+		expected []string
+	}{
+		{selector: "item > title", expected: []string{"First", "Second"}},
+		{selector: "link", expected: []string{"https://example.com/1", "https://example.com/2"}},
+		{selector: "pubDate", expected: []string{"Tue, 02 Jan 2024 03:04:05 +0000"}},
+		{selector: "pubdate", expected: []string{}},
+		{selector: "Item", expected: []string{"Uppercase"}},
+		{selector: "media|content:attr(url)", expected: []string{"https://example.com/1.jpg"}},
+		{selector: `media\:content[medium="image"]:attr(url)`, expected: []string{"https://example.com/1.jpg"}},
+		{selector: "guid[isPermaLink=false]:attr(isPermaLink)", expected: []string{"false"}},
+		{selector: "dc|date:date", expected: []string{"2024-01-03T00:00:00Z"}},
+		{selector: "item:get(2) > title", expected: []string{}},
+		{selector: "item > title:get(2)", expected: []string{"Second"}},
+		{selector: "description", expected: []string{"<p>Text &amp; <b>bold</b></p>"}},
+		{selector: "item:has(media|content) guid:outerhtml", expected: []string{`<guid isPermaLink="false">id-1</guid>`}},
+		{selector: "item > title:contains(Second)", expected: []string{"Second"}},
+		{selector: "Item:contains(Uppercase)", expected: []string{"Uppercase"}},
+		{selector: "item:has(title:contains(First)) > link", expected: []string{"https://example.com/1"}},
+		{selector: "media|content:outerhtml", expected: []string{`<media:content url="https://example.com/1.jpg" medium="image"/>`}},
+	}
+
+	for i, item := range testData {
+		texts, err := doc.GetData(map[string]string{"one": item.selector})
+		if err != nil {
+			t.Errorf("%d. GetData(%q) got error: %s", i, item.selector, err)
+			continue
+		}
+		if !reflect.DeepEqual(item.expected, texts["one"]) {
+			t.Errorf("%d. GetData(%q)\nexpected: %#v\nreal    : %#v", i, item.selector, item.expected, texts["one"])
+		}
+	}
+
+	items, err := doc.GetDataNestedFirst("item", map[string]string{"title": "title", "image": "media|content:attr(url)"})
+	expected := []map[string]string{{"title": "First", "image": "https://example.com/1.jpg"}, {"title": "Second", "image": ""}}
+	if err != nil || !reflect.DeepEqual(expected, items) {
+		t.Errorf("GetDataNestedFirst()\nexpected: %#v\nreal    : %#v, %v", expected, items, err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = fmt.Fprint(w, "<?xml version=\"1.0\" encoding=\"windows-1251\"?><root><Name>\xcf\xf0\xe8\xe2\xe5\xf2</Name></root>")
+	}))
+	defer ts.Close()
+	if name, err := FromURL(ts.URL, URLCfg{XML: true}).GetDataSingle("Name"); err != nil || name != "Привет" {
+		t.Errorf("FromURL() with XML: got: %q, %v", name, err)
+	}
+
+	if doc := FromReaderXML(strings.NewReader("<a><b></a>")); doc.Err != nil {
+		t.Errorf("FromReaderXML() with unclosed element got error: %s", doc.Err)
+	}
+}
+
+func Test_xmlSelector(t *testing.T) {
+	testData := map[string]string{
+		"item > title":              "item > title",
+		"pubDate":                   "pub^date",
+		"media|content":             `media\:content`,
+		`media\:content`:            `media\:content`,
+		"Item.Class#Id:first-child": "^item.Class#Id:first-child",
+		`guid[isPermaLink="Yes"]`:   "guid[is^perma^link=\"Yes\"]",
+		"a[rel=Alternate], Entry":   "a[rel=Alternate], ^entry",
+		":not(Item)":                ":not(^item)",
+		`title[lang|="EN"]`:         `title[lang|="EN"]`,
+		"Title:contains(Foo)":       "^title:contains(Foo)",
+		`Title:contains("Foo")`:     `^title:contains("Foo")`,
+		"Title:contains(Foo (Bar))": "^title:contains(Foo (Bar))",
+		"Item:nth-child(2N+1) > A":  "^item:nth-child(2N+1) > ^a",
+		":not(Item:contains(X))":    ":not(^item:contains(X))",
+	}
+
+	for selector, expected := range testData {
+		// "^" - mark of uppercase letter
+		expected = strings.ReplaceAll(expected, "^", string(xmlUpperMark))
+		if got := xmlSelector(selector); got != expected {
+			t.Errorf("xmlSelector(%q): expected: %q, got: %q", selector, expected, got)
+		}
+	}
+}